@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/webhog/internal/scanner"
+	"github.com/user/webhog/internal/ui"
+)
+
+var baselineOpts struct {
+	output  string
+	reason  string
+	expires string
+}
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline",
+	Short: "Manage the ignore file of accepted findings",
+}
+
+var baselineCreateCmd = &cobra.Command{
+	Use:   "create <results.json>",
+	Short: "Suppress every finding of a saved scan",
+	Long: `Append the fingerprints of every finding in a result saved with
+"webhog scan --format json" to an ignore file, so that later scans only
+report findings that are new since then.
+
+Fingerprints already in the file are skipped, so the command can be rerun
+after each reviewed scan.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBaselineCreate,
+}
+
+func init() {
+	baselineCreateCmd.Flags().StringVarP(&baselineOpts.output, "output", "o", scanner.DefaultIgnoreFile, "ignore file to append to")
+	baselineCreateCmd.Flags().StringVar(&baselineOpts.reason, "reason", "", "reason recorded with each suppression")
+	baselineCreateCmd.Flags().StringVar(&baselineOpts.expires, "expires", "", "date (YYYY-MM-DD) after which the suppressions lapse")
+
+	baselineCmd.AddCommand(baselineCreateCmd)
+	rootCmd.AddCommand(baselineCmd)
+}
+
+func runBaselineCreate(cmd *cobra.Command, args []string) error {
+	if baselineOpts.expires != "" {
+		if _, err := time.Parse("2006-01-02", baselineOpts.expires); err != nil {
+			return fmt.Errorf("invalid --expires %q (want YYYY-MM-DD)", baselineOpts.expires)
+		}
+	}
+	switch strings.ToLower(filepath.Ext(baselineOpts.output)) {
+	case ".yaml", ".yml":
+		return fmt.Errorf("baseline create writes the line-based ignore format, not YAML")
+	}
+	if strings.ContainsAny(baselineOpts.reason, ";\n") {
+		return fmt.Errorf("--reason cannot contain ';' or newlines")
+	}
+
+	targets, err := ui.LoadJSONFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	existing, err := existingFingerprints(baselineOpts.output)
+	if err != nil {
+		return err
+	}
+
+	// A finding seen on several pages counts once, as one suppression
+	var b strings.Builder
+	added, present := 0, 0
+	seen := make(map[string]bool)
+	for _, t := range targets {
+		for _, f := range t.Findings {
+			fp := strings.ToLower(f.Fingerprint)
+			if fp == "" {
+				fp = scanner.Fingerprint(f)
+			}
+			if seen[fp] {
+				continue
+			}
+			seen[fp] = true
+			if existing[fp] {
+				present++
+				continue
+			}
+			added++
+
+			fmt.Fprintf(&b, "# %s at %s\n", f.Detector, f.Location())
+			if baselineOpts.reason == "" && baselineOpts.expires == "" {
+				fmt.Fprintf(&b, "%s\n", fp)
+				continue
+			}
+			fmt.Fprintf(&b, "fingerprint=%s", fp)
+			if baselineOpts.expires != "" {
+				fmt.Fprintf(&b, "; expires=%s", baselineOpts.expires)
+			}
+			if baselineOpts.reason != "" {
+				fmt.Fprintf(&b, "; reason=%s", baselineOpts.reason)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if added > 0 {
+		file, err := os.OpenFile(baselineOpts.output, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open ignore file: %w", err)
+		}
+		defer file.Close()
+
+		if _, err := file.WriteString(b.String()); err != nil {
+			return fmt.Errorf("failed to write ignore file: %w", err)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Added %d suppressions to %s (%d already present)\n",
+		added, baselineOpts.output, present)
+	return nil
+}
+
+// existingFingerprints returns the bare fingerprints already in an ignore
+// file, or none if the file does not exist yet
+func existingFingerprints(path string) (map[string]bool, error) {
+	fingerprints := make(map[string]bool)
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return fingerprints, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ignore file: %w", err)
+	}
+	defer file.Close()
+
+	sc := bufio.NewScanner(file)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.Contains(line, "=") {
+			fingerprints[strings.ToLower(line)] = true
+			continue
+		}
+		for _, pair := range strings.Split(line, ";") {
+			key, value, _ := strings.Cut(pair, "=")
+			if strings.EqualFold(strings.TrimSpace(key), "fingerprint") {
+				fingerprints[strings.ToLower(strings.TrimSpace(value))] = true
+			}
+		}
+	}
+	return fingerprints, sc.Err()
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user/webhog/internal/ui"
+)
+
+var diffOpts struct {
+	json bool
+	all  bool
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <old.json> <new.json>",
+	Short: "Show new, resolved and persisting findings between two scans",
+	Long: `Compare two results saved with "webhog scan --format json".
+
+Findings are matched by fingerprint: the detector, the file path without
+query string or content hashes, and a hash of the token. The command exits
+with status 3 when the newer scan has findings the older one did not.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	diffCmd.Flags().BoolVar(&diffOpts.json, "json", false, "output the diff as JSON")
+	diffCmd.Flags().BoolVar(&diffOpts.all, "all", false, "also list persisting findings")
+
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	older, err := ui.LoadJSONFile(args[0])
+	if err != nil {
+		return err
+	}
+	newer, err := ui.LoadJSONFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	format := ui.FormatText
+	if diffOpts.json {
+		format = ui.FormatJSON
+	}
+	d := ui.Compare(older, newer)
+	if err := ui.NewOutputter(cfg.NoColor, format, false).OutputDiff(os.Stdout, d, diffOpts.all); err != nil {
+		return err
+	}
+
+	if len(d.New) > 0 {
+		return exitWith(cmd, exitFindings)
+	}
+	return nil
+}
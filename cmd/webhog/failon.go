@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/user/webhog/internal/scanner"
+	"github.com/user/webhog/internal/ui"
+)
+
+// failPolicy decides from the findings of a scan whether it should fail
+type failPolicy struct {
+	types       map[scanner.DetectorType]bool // Empty for any type
+	minSeverity scanner.Severity              // Empty for any severity
+	threshold   int                           // Matching findings needed to fail
+}
+
+// parseFailOn parses the --fail-on terms: finding types, a minimum severity
+// and a count threshold, e.g. "secret,config,high,5". Types are alternatives;
+// the severity and threshold narrow them further.
+func parseFailOn(terms []string) (*failPolicy, error) {
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	p := &failPolicy{types: make(map[scanner.DetectorType]bool), threshold: 1}
+	for _, term := range terms {
+		term = strings.ToLower(strings.TrimSpace(term))
+		switch term {
+		case "", "any":
+			continue
+		case "secret", "secrets":
+			p.types[scanner.DetectorSecret] = true
+			continue
+		case "config", "configuration":
+			p.types[scanner.DetectorConfig] = true
+			continue
+		case "endpoint", "endpoints":
+			p.types[scanner.DetectorEndpoint] = true
+			continue
+		case "generic":
+			p.types[scanner.DetectorGeneric] = true
+			continue
+		}
+
+		if sev, ok := scanner.ParseSeverity(term); ok {
+			if p.minSeverity != "" {
+				return nil, fmt.Errorf("invalid --fail-on: more than one severity given")
+			}
+			p.minSeverity = sev
+			continue
+		}
+		if n, err := strconv.Atoi(term); err == nil && n > 0 {
+			p.threshold = n
+			continue
+		}
+		return nil, fmt.Errorf("invalid --fail-on term %q (want a type: secret, config, endpoint, generic; a severity: critical, high, medium, low, info; or a count)", term)
+	}
+	return p, nil
+}
+
+// matches reports whether a finding counts towards the threshold
+func (p *failPolicy) matches(f scanner.Finding) bool {
+	if len(p.types) > 0 && !p.types[f.Type] {
+		return false
+	}
+	if p.minSeverity != "" && f.Severity.Rank() < p.minSeverity.Rank() {
+		return false
+	}
+	return true
+}
+
+// count returns how many findings across targets match the policy
+func (p *failPolicy) count(targets []ui.Target) int {
+	n := 0
+	for _, t := range targets {
+		for _, f := range t.Findings {
+			if p.matches(f) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// partialFailure reports whether some targets or crawled pages could not
+// be fetched
+func partialFailure(targets []ui.Target) bool {
+	for _, t := range targets {
+		if t.Error != "" {
+			return true
+		}
+		for _, p := range t.Pages() {
+			if p.Error != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
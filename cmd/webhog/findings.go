@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/user/webhog/internal/store"
+)
+
+var findingsOpts struct {
+	target   string
+	detector string
+	status   string
+	since    string
+	until    string
+	limit    int
+	json     bool
+}
+
+var findingsCmd = &cobra.Command{
+	Use:   "findings",
+	Short: "Query findings recorded with --save",
+	Long: `Query the findings recorded by "webhog scan --save".
+
+A finding is open while it keeps showing up in scans of its target, and
+resolved once a successful scan of that target no longer finds it.`,
+	Args: cobra.NoArgs,
+	RunE: runFindings,
+}
+
+func init() {
+	findingsCmd.Flags().StringVar(&findingsOpts.target, "target", "", "only targets whose URL contains this text")
+	findingsCmd.Flags().StringVar(&findingsOpts.detector, "detector", "", "only findings from this detector")
+	findingsCmd.Flags().StringVar(&findingsOpts.status, "status", "open", "open, resolved or all")
+	findingsCmd.Flags().StringVar(&findingsOpts.since, "since", "", "only findings seen on or after this date (YYYY-MM-DD or RFC 3339)")
+	findingsCmd.Flags().StringVar(&findingsOpts.until, "until", "", "only findings seen on or before this date (YYYY-MM-DD or RFC 3339)")
+	findingsCmd.Flags().IntVar(&findingsOpts.limit, "limit", 0, "maximum number of findings (0 = no limit)")
+	findingsCmd.Flags().BoolVar(&findingsOpts.json, "json", false, "output as JSON")
+
+	rootCmd.AddCommand(findingsCmd)
+}
+
+func runFindings(cmd *cobra.Command, args []string) error {
+	since, until, err := parseDateRange(findingsOpts.since, findingsOpts.until)
+	if err != nil {
+		return err
+	}
+
+	status := findingsOpts.status
+	switch status {
+	case store.StatusOpen, store.StatusResolved:
+	case "all":
+		status = ""
+	default:
+		return fmt.Errorf("invalid --status %q (want open, resolved or all)", status)
+	}
+
+	db, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	findings, err := db.Findings(context.Background(), store.FindingFilter{
+		Target:   findingsOpts.target,
+		Detector: findingsOpts.detector,
+		Status:   status,
+		Since:    since,
+		Until:    until,
+		Limit:    findingsOpts.limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if findingsOpts.json {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(findings)
+	}
+
+	if len(findings) == 0 {
+		fmt.Println("No findings.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tSEVERITY\tDETECTOR\tLOCATION\tTOKEN\tFIRST SEEN\tLAST SEEN")
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s:%d\t%s\t%s\t%s\n", f.Status, f.Severity, f.Detector, f.Path, f.LineNum,
+			f.Token, f.FirstSeen.Local().Format("2006-01-02"), f.LastSeen.Local().Format("2006-01-02"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/user/webhog/internal/store"
+)
+
+var historyOpts struct {
+	target string
+	since  string
+	until  string
+	limit  int
+	json   bool
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List past scans recorded with --save",
+	Args:  cobra.NoArgs,
+	RunE:  runHistory,
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historyOpts.target, "target", "", "only targets whose URL contains this text")
+	historyCmd.Flags().StringVar(&historyOpts.since, "since", "", "only scans on or after this date (YYYY-MM-DD or RFC 3339)")
+	historyCmd.Flags().StringVar(&historyOpts.until, "until", "", "only scans on or before this date (YYYY-MM-DD or RFC 3339)")
+	historyCmd.Flags().IntVar(&historyOpts.limit, "limit", 50, "maximum number of entries (0 = no limit)")
+	historyCmd.Flags().BoolVar(&historyOpts.json, "json", false, "output as JSON")
+
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	since, until, err := parseDateRange(historyOpts.since, historyOpts.until)
+	if err != nil {
+		return err
+	}
+
+	db, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	entries, err := db.History(context.Background(), store.HistoryFilter{
+		Target: historyOpts.target,
+		Since:  since,
+		Until:  until,
+		Limit:  historyOpts.limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	if historyOpts.json {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No scans recorded.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SCAN\tTIME\tTARGET\tPAGES\tJS BLOBS\tFINDINGS\tTECHNOLOGIES")
+	for _, e := range entries {
+		findings := fmt.Sprint(e.Findings)
+		if e.Error != "" {
+			findings = "error: " + e.Error
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%d\t%s\t%s\n", e.ScanID, e.Time.Local().Format("2006-01-02 15:04"),
+			e.URL, e.Pages, e.JSBlobs, findings, strings.Join(e.Technologies, ", "))
+	}
+	return w.Flush()
+}
+
+// dbPath returns the findings database location
+func dbPath() string {
+	if cfg.DBPath != "" {
+		return cfg.DBPath
+	}
+	return store.DefaultPath()
+}
+
+// openStore opens the findings database
+func openStore() (*store.Store, error) {
+	db, err := store.Open(dbPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open findings database: %w", err)
+	}
+	return db, nil
+}
+
+// parseDateRange parses --since/--until values. A bare date as the upper
+// bound includes that whole day.
+func parseDateRange(since, until string) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+	if since != "" {
+		if from, _, err = parseDate(since); err != nil {
+			return from, to, fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if until != "" {
+		var dateOnly bool
+		if to, dateOnly, err = parseDate(until); err != nil {
+			return from, to, fmt.Errorf("invalid --until: %w", err)
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+	return from, to, nil
+}
+
+// parseDate parses a local date or an RFC 3339 timestamp, reporting
+// whether only a date was given
+func parseDate(s string) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, false, fmt.Errorf("%q is not a YYYY-MM-DD date or RFC 3339 time", s)
+	}
+	return t, false, nil
+}
@@ -1,11 +1,43 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes other than 0 (success) and 1 (error)
+const (
+	exitPartial  = 2 // Some targets or crawled pages could not be fetched
+	exitFindings = 3 // Findings that should fail the run were found
 )
 
+// exitCodeError ends the program with a specific exit code once the
+// command's output has been written
+type exitCodeError struct {
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// exitWith returns an error that makes the command exit with code, without
+// cobra printing it or the usage
+func exitWith(cmd *cobra.Command, code int) error {
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return &exitCodeError{code: code}
+}
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
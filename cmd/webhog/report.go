@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/user/webhog/internal/ui"
+)
+
+var reportOutput string
+
+var reportCmd = &cobra.Command{
+	Use:   "report <results.json>",
+	Short: "Convert saved JSON results into an HTML report",
+	Long: `Convert results saved with "webhog scan --format json" into a single-file
+HTML report that can be handed over as is.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReport,
+}
+
+func init() {
+	reportCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "write the report to file instead of stdout")
+
+	rootCmd.AddCommand(reportCmd)
+}
+
+func runReport(cmd *cobra.Command, args []string) error {
+	targets, err := ui.LoadJSONFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if reportOutput != "" {
+		f, err := os.Create(reportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	outputter := ui.NewOutputter(true, ui.FormatHTML, false)
+	outputter.SetVersion(rootCmd.Version)
+	return outputter.Output(w, targets)
+}
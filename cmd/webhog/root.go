@@ -1,27 +1,125 @@
 package main
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/user/webhog/internal/config"
 )
 
-var cfg = &config.Config{}
+var cfg = config.NewConfig()
 
 var rootCmd = &cobra.Command{
 	Use:   "webhog",
 	Short: "A headless web secret scanner",
 	Long: `Webhog is a CLI tool that scans web pages for secrets, API keys,
 tokens, and interesting endpoints. It supports both static (HTTP-only)
-and headless browser modes for JavaScript-heavy applications.`,
-	Version: "0.1.0",
+and headless browser modes for JavaScript-heavy applications.
+
+The global flags and the flags of the scan command can also be set in a
+YAML or TOML config file (by default ~/.config/webhog/config.yaml, or the
+--config path) using the flag name as the key, or with a WEBHOG_*
+environment variable (e.g. WEBHOG_MAX_DEPTH=2). Flags take precedence over
+the environment, which takes precedence over the config file. Other
+commands only take the global flags from there, so a scan's "output"
+setting cannot redirect what they write.`,
+	Version:           "0.1.0",
+	PersistentPreRunE: applyConfig,
 }
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVarP(&cfg.Verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVar(&cfg.NoColor, "no-color", false, "disable colored output")
-	rootCmd.PersistentFlags().StringVar(&cfg.ConfigFile, "config", "", "config file path")
+	rootCmd.PersistentFlags().StringVar(&cfg.ConfigFile, "config", "", "config file path (default ~/.config/webhog/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfg.DBPath, "db", "", "findings database path (default ~/.local/share/webhog/webhog.db)")
 
 	// Add subcommands
 	rootCmd.AddCommand(scanCmd)
 }
+
+// applyConfig fills in the configurable flags the user did not set
+// explicitly, first from WEBHOG_* environment variables and then from the
+// config file. Settings are scan settings: only the global flags and those
+// of the scan command can be configured.
+func applyConfig(cmd *cobra.Command, args []string) error {
+	env := config.FromEnv(os.Environ())
+	flags := cmd.Flags()
+
+	// Locate the config file: --config, then WEBHOG_CONFIG, then the
+	// default location if it exists
+	if !flags.Changed("config") {
+		if path, ok := env["config"]; ok {
+			cfg.ConfigFile = path
+		} else if path := config.DefaultPath(); path != "" {
+			if _, err := os.Stat(path); err == nil {
+				cfg.ConfigFile = path
+			}
+		}
+	}
+
+	file := config.Values{}
+	if cfg.ConfigFile != "" {
+		values, err := config.LoadFile(cfg.ConfigFile)
+		if err != nil {
+			return err
+		}
+		file = values
+	}
+
+	// Reject keys that are not configurable, so typos don't go unnoticed
+	known := configurable(cmd.Root())
+	for key := range file {
+		if !known[key] {
+			return fmt.Errorf("%s: unknown setting %q", cfg.ConfigFile, key)
+		}
+	}
+
+	var applyErr error
+	flags.VisitAll(func(f *pflag.Flag) {
+		if applyErr != nil || f.Changed || f.Name == "config" {
+			return
+		}
+		// Flags of other commands may share a name with a scan flag, like
+		// baseline create's --output
+		if cmd.Name() != configuredCommand && cmd.Root().PersistentFlags().Lookup(f.Name) == nil {
+			return
+		}
+
+		if value, ok := env[f.Name]; ok {
+			if err := f.Value.Set(value); err != nil {
+				applyErr = fmt.Errorf("%s: %w", config.EnvName(f.Name), err)
+			}
+			return
+		}
+
+		if value, ok := file[f.Name]; ok {
+			if err := f.Value.Set(value); err != nil {
+				applyErr = fmt.Errorf("%s: %s: %w", cfg.ConfigFile, f.Name, err)
+			}
+		}
+	})
+
+	return applyErr
+}
+
+// configuredCommand is the command whose flags settings apply to
+const configuredCommand = "scan"
+
+// configurable returns the names of the flags that settings apply to: the
+// global flags and those of the scan command
+func configurable(root *cobra.Command) map[string]bool {
+	known := make(map[string]bool)
+	add := func(f *pflag.Flag) {
+		known[f.Name] = true
+	}
+	root.PersistentFlags().VisitAll(add)
+	for _, c := range root.Commands() {
+		if c.Name() == configuredCommand {
+			c.Flags().VisitAll(add)
+		}
+	}
+	return known
+}
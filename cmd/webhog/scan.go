@@ -4,23 +4,35 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/user/webhog/internal/crawler"
+	"github.com/user/webhog/internal/pool"
 	"github.com/user/webhog/internal/renderer"
 	"github.com/user/webhog/internal/scanner"
 	"github.com/user/webhog/internal/tech"
 	"github.com/user/webhog/internal/ui"
+	"github.com/user/webhog/internal/verify"
 )
 
 var scanCmd = &cobra.Command{
-	Use:   "scan [url]",
-	Short: "Scan a URL for secrets and interesting endpoints",
-	Long: `Scan a web page for exposed secrets, API keys, tokens, and interesting endpoints.
+	Use:   "scan [url...]",
+	Short: "Scan URLs for secrets and interesting endpoints",
+	Long: `Scan web pages for exposed secrets, API keys, tokens, and interesting endpoints.
 
 By default, uses static HTTP fetching. Use --headless to enable browser rendering
-for JavaScript-heavy applications.`,
-	Args: cobra.ExactArgs(1),
+for JavaScript-heavy applications.
+
+Several targets can be scanned in one run: pass multiple URLs, a file of
+newline-delimited URLs with -l, or pipe URLs on stdin (or pass "-").
+
+Exit status is 0 on success, 1 when the scan cannot run or no target could
+be fetched, 2 when some targets or crawled pages failed, and 3 when findings
+match --fail-on or are new since --baseline.`,
+	Args: cobra.ArbitraryArgs,
 	RunE: runScan,
 }
 
@@ -28,60 +40,165 @@ func init() {
 	// Mode flags
 	scanCmd.Flags().BoolVar(&cfg.Headless, "headless", false, "use headless browser rendering")
 	scanCmd.Flags().DurationVar(&cfg.Timeout, "timeout", 30*time.Second, "page load timeout")
+	scanCmd.Flags().IntVar(&cfg.Tabs, "tabs", 4, "maximum open browser tabs in headless mode")
+
+	// Target flags
+	scanCmd.Flags().StringVarP(&cfg.TargetsFile, "list", "l", "", "file with newline-delimited target URLs")
 
 	// Crawl flags
 	scanCmd.Flags().IntVar(&cfg.MaxDepth, "max-depth", 0, "maximum crawl depth (0 = single URL only)")
 	scanCmd.Flags().BoolVar(&cfg.SameDomain, "same-domain", false, "only follow links on same domain")
+	scanCmd.Flags().IntVar(&cfg.Concurrency, "concurrency", 10, "maximum concurrent requests, page renders and blob scans")
 
 	// Output flags
-	scanCmd.Flags().BoolVar(&cfg.JSONOutput, "json", false, "output results as JSON")
+	scanCmd.Flags().StringVar(&cfg.Format, "format", "text", "output format: text, json, ndjson, sarif or html")
+	scanCmd.Flags().BoolVar(&cfg.JSONOutput, "json", false, "output results as JSON (same as --format json)")
 	scanCmd.Flags().BoolVar(&cfg.Quiet, "quiet", false, "minimal output")
 	scanCmd.Flags().BoolVar(&cfg.PlainOutput, "plain", false, "disable styled output")
 	scanCmd.Flags().StringVarP(&cfg.OutputFile, "output", "o", "", "write results to file")
+	scanCmd.Flags().StringVar(&cfg.Redact, "redact", "", "mask secret values in all output: full, partial or hash")
+	scanCmd.Flags().StringVar(&cfg.Baseline, "baseline", "", "JSON results of an earlier scan; report new and resolved findings and exit 3 if any are new")
+	scanCmd.Flags().StringSliceVar(&cfg.FailOn, "fail-on", nil, "exit 3 when findings match: types (secret, config, endpoint, generic), a minimum severity and/or a count, e.g. secret,high,2")
 
 	// Detection flags
+	scanCmd.Flags().StringSliceVar(&cfg.RulesFiles, "rules", nil, "YAML/JSON/TOML file with custom detector rules (repeatable)")
 	scanCmd.Flags().BoolVar(&cfg.IncludeEntropy, "include-entropy", false, "enable entropy-based detection")
 	scanCmd.Flags().Float64Var(&cfg.MinEntropy, "min-entropy", 4.5, "minimum entropy threshold")
 	scanCmd.Flags().IntVar(&cfg.MinLength, "min-length", 20, "minimum token length for detection")
+	scanCmd.Flags().BoolVar(&cfg.Beautify, "beautify", false, "pretty-print minified JavaScript before scanning; locations still refer to the original")
+	scanCmd.Flags().IntVar(&cfg.DecodeDepth, "decode-depth", scanner.DefaultDecodeDepth, "nested encodings (base64, hex, URL, \\u escapes, data: URIs) to decode and rescan (0 = off)")
+	scanCmd.Flags().StringVar(&cfg.MinSeverity, "min-severity", "info", "only report findings at or above this severity (critical, high, medium, low, info)")
+	scanCmd.Flags().StringSliceVar(&cfg.IgnoreFiles, "ignore", nil, "ignore file of suppressed findings (repeatable; default ./"+scanner.DefaultIgnoreFile+" if present)")
+
+	// Database flags
+	scanCmd.Flags().BoolVar(&cfg.Save, "save", false, "record the scan and its findings in the findings database (see --db)")
+
+	// Verification flags
+	scanCmd.Flags().BoolVar(&cfg.Verify, "verify", false, "check found secrets against the provider APIs")
+	scanCmd.Flags().StringToStringVar(&cfg.VerifyEndpoints, "verify-endpoint", nil, "override a provider API base URL, e.g. github=http://localhost:8080")
 }
 
 func runScan(cmd *cobra.Command, args []string) error {
-	targetURL := args[0]
+	started := time.Now()
 
-	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
-	defer cancel()
+	targetURLs, err := collectTargets(args, cfg.TargetsFile)
+	if err != nil {
+		return err
+	}
 
-	// Select renderer
-	var r renderer.Renderer
-	if cfg.Headless {
-		r = renderer.NewHeadlessRenderer(cfg.Timeout)
-	} else {
-		r = renderer.NewStaticRenderer(cfg.Timeout)
+	// Custom rules may live in the config file as well as in --rules files
+	var rulesFiles []string
+	if cfg.ConfigFile != "" {
+		rulesFiles = append(rulesFiles, cfg.ConfigFile)
 	}
+	rulesFiles = append(rulesFiles, cfg.RulesFiles...)
 
-	// Render the page
-	if cfg.Verbose && !cfg.Quiet {
-		fmt.Fprintf(os.Stderr, "Rendering %s...\n", targetURL)
+	format, ok := ui.ParseFormat(cfg.Format)
+	if !ok {
+		return fmt.Errorf("invalid --format %q (want text, json, ndjson, sarif or html)", cfg.Format)
+	}
+	if cfg.JSONOutput {
+		format = ui.FormatJSON
+	}
+
+	minSeverity, ok := scanner.ParseSeverity(cfg.MinSeverity)
+	if !ok {
+		return fmt.Errorf("invalid --min-severity %q (want critical, high, medium, low or info)", cfg.MinSeverity)
 	}
 
-	result, err := r.Render(ctx, targetURL)
+	redact, ok := scanner.ParseRedactMode(cfg.Redact)
+	if !ok {
+		return fmt.Errorf("invalid --redact %q (want full, partial or hash)", cfg.Redact)
+	}
+
+	if cfg.DecodeDepth < 0 {
+		return fmt.Errorf("invalid --decode-depth %d (want 0 or more)", cfg.DecodeDepth)
+	}
+
+	failOn, err := parseFailOn(cfg.FailOn)
 	if err != nil {
-		return fmt.Errorf("failed to render page: %w", err)
+		return err
 	}
 
-	if cfg.Verbose && !cfg.Quiet {
-		fmt.Fprintf(os.Stderr, "Found %d JS blobs\n", len(result.JSBlobs))
+	detectors, err := scanner.LoadDetectors(rulesFiles...)
+	if err != nil {
+		return fmt.Errorf("failed to load rules: %w", err)
 	}
 
-	// Detect technologies
-	var technologies []string
+	suppressions, err := loadSuppressions()
+	if err != nil {
+		return err
+	}
+
+	// Live verification is opt-in since it sends secrets to their providers
+	var verifier *verify.Verifier
+	if cfg.Verify {
+		verifier, err = verify.New(cfg.VerifyEndpoints, 10*time.Second)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Load the baseline up front so a bad path fails before scanning
+	var baseline []ui.Target
+	if cfg.Baseline != "" {
+		baseline, err = ui.LoadJSONFile(cfg.Baseline)
+		if err != nil {
+			return fmt.Errorf("failed to load baseline: %w", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// One worker pool bounds all network and scanning work
+	workers := pool.New(cfg.Concurrency)
+
+	// Select renderer
+	var r renderer.Renderer
+	if cfg.Headless {
+		r = renderer.NewHeadlessRenderer(cfg.Timeout, workers, cfg.Tabs)
+	} else {
+		r = renderer.NewStaticRenderer(cfg.Timeout, workers)
+	}
+	defer r.Close()
+
+	s := scanner.NewScanner(cfg.IncludeEntropy, cfg.MinEntropy, cfg.MinLength)
+	s.SetDetectors(detectors)
+	s.SetPool(workers)
+	s.SetMinSeverity(minSeverity)
+	s.SetSuppressions(suppressions)
+	s.SetRedact(redact)
+	s.SetDecodeDepth(cfg.DecodeDepth)
+	s.SetBeautify(cfg.Beautify)
+	if verifier != nil {
+		s.SetVerifier(verifier)
+	}
+	c := crawler.NewCrawler(r, s, cfg.MaxDepth, cfg.SameDomain, cfg.Concurrency)
+
+	// Technology detection is shared by all targets
 	d, err := tech.NewDetector()
-	if err == nil {
-		technologies = d.Analyze(result.Headers, []byte(result.HTML))
+	if err != nil && cfg.Verbose {
+		fmt.Fprintf(os.Stderr, "Technology detection disabled: %v\n", err)
 	}
 
 	// Outputter
-	outputter := ui.NewOutputter(cfg.NoColor || cfg.PlainOutput, cfg.JSONOutput, cfg.Quiet)
+	outputter := ui.NewOutputter(cfg.NoColor || cfg.PlainOutput, format, cfg.Quiet)
+	outputter.SetVersion(rootCmd.Version)
+	outputter.SetDetectors(detectors)
+
+	// Report pages as they are crawled
+	c.OnPage(func(p crawler.Page) {
+		outputter.PrintPage(os.Stdout, p)
+		if !cfg.Verbose || cfg.Quiet {
+			return
+		}
+		if p.Error != "" {
+			fmt.Fprintf(os.Stderr, "[depth %d] %s: %s\n", p.Depth, p.URL, p.Error)
+			return
+		}
+		fmt.Fprintf(os.Stderr, "[depth %d] %s: %d JS blobs\n", p.Depth, p.URL, p.JSBlobs)
+	})
 
 	// File outputter (if needed)
 	var fileOutputter *ui.Outputter
@@ -94,59 +211,206 @@ func runScan(cmd *cobra.Command, args []string) error {
 		defer f.Close()
 		file = f
 		// Force plain text and no quiet for file output
-		fileOutputter = ui.NewOutputter(true, cfg.JSONOutput, false)
+		fileOutputter = ui.NewOutputter(true, format, false)
+		fileOutputter.SetVersion(rootCmd.Version)
+		fileOutputter.SetDetectors(detectors)
+	}
+
+	// Print header for file
+	if fileOutputter != nil && format == ui.FormatText {
+		fmt.Fprintf(file, "Webhog Scan Results\n")
+		fmt.Fprintf(file, "===================\n\n")
+	}
+
+	var targets []ui.Target
+	for _, targetURL := range targetURLs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if len(targetURLs) > 1 || format == ui.FormatNDJSON {
+			outputter.PrintTarget(os.Stdout, targetURL)
+		}
+		if cfg.Verbose && !cfg.Quiet {
+			fmt.Fprintf(os.Stderr, "Rendering %s...\n", targetURL)
+		}
+
+		target := scanTarget(ctx, c, d, outputter, targetURL)
+		target.Suppressed = s.TakeSuppressed()
+		outputter.PrintResult(os.Stdout, target)
+		targets = append(targets, target)
+	}
+
+	if cfg.Save {
+		if err := saveScan(started, targets); err != nil {
+			return err
+		}
+	}
+
+	// After streaming finishes, we have all findings in each target.
+	// Note: StreamOutput already handles deduplication internally for display options.
+	// Writing to the file at the end is simpler than teeing the stream.
+	if fileOutputter != nil {
+		fileOutputter.Output(file, targets)
+	}
+
+	if format.Structured() {
+		if err := outputter.Output(os.Stdout, targets); err != nil {
+			return err
+		}
+	} else if !cfg.Quiet || format == ui.FormatNDJSON {
+		// For normal output, print the summary box at the end; a stream of
+		// NDJSON events always ends with its summary
+		outputter.PrintSummary(os.Stdout, targets)
+	}
+
+	return exitStatus(cmd, format, failOn, baseline, targets)
+}
+
+// exitStatus picks the exit code of a finished scan: findings that fail the
+// run take precedence over targets and pages that could not be fetched
+func exitStatus(cmd *cobra.Command, format ui.Format, failOn *failPolicy, baseline, targets []ui.Target) error {
+	fail := false
+	if baseline != nil && checkBaseline(format, baseline, targets) {
+		fail = true
+	}
+	if failOn != nil {
+		if n := failOn.count(targets); n >= failOn.threshold {
+			fmt.Fprintf(os.Stderr, "%d findings match --fail-on %s\n", n, strings.Join(cfg.FailOn, ","))
+			fail = true
+		}
+	}
+	if fail {
+		return exitWith(cmd, exitFindings)
+	}
+
+	failed := 0
+	for _, t := range targets {
+		if t.Error != "" {
+			failed++
+		}
+	}
+	if failed > 0 && failed == len(targets) {
+		// A fetch failure is not a usage error
+		cmd.SilenceUsage = true
+	}
+	switch {
+	case failed == 1 && len(targets) == 1:
+		return fmt.Errorf("%s", targets[0].Error)
+	case failed > 0 && failed == len(targets):
+		return fmt.Errorf("all %d targets failed", failed)
+	}
+	if partialFailure(targets) {
+		return exitWith(cmd, exitPartial)
+	}
+	return nil
+}
+
+// loadSuppressions reads the --ignore files, falling back to the default
+// ignore file in the working directory when no flag was given
+func loadSuppressions() ([]scanner.Suppression, error) {
+	files := cfg.IgnoreFiles
+	if len(files) == 0 {
+		if _, err := os.Stat(scanner.DefaultIgnoreFile); err != nil {
+			return nil, nil
+		}
+		files = []string{scanner.DefaultIgnoreFile}
+	}
+
+	var suppressions []scanner.Suppression
+	for _, path := range files {
+		loaded, err := scanner.LoadIgnoreFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ignore file: %w", err)
+		}
+		suppressions = append(suppressions, loaded...)
+	}
+
+	if cfg.Verbose && !cfg.Quiet {
+		now := time.Now()
+		for _, sup := range suppressions {
+			if sup.Expired(now) {
+				fmt.Fprintf(os.Stderr, "Suppression at %s expired on %s\n", sup.Source, sup.Expires.Format("2006-01-02"))
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Loaded %d suppressions\n", len(suppressions))
+	}
+	return suppressions, nil
+}
+
+// checkBaseline reports how the findings changed since the baseline scan
+// and whether there are new ones. Structured output stays a single
+// document, so the diff is only summarized on stderr there.
+func checkBaseline(format ui.Format, baseline, targets []ui.Target) bool {
+	d := ui.Compare(baseline, targets)
+
+	if format == ui.FormatText {
+		if !cfg.Quiet {
+			ui.NewOutputter(cfg.NoColor || cfg.PlainOutput, format, false).OutputDiff(os.Stdout, d, false)
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Baseline %s: %d new, %d resolved, %d persisting\n",
+			cfg.Baseline, len(d.New), len(d.Resolved), len(d.Persisting))
+	}
+
+	return len(d.New) > 0
+}
+
+// saveScan records the scan in the findings database
+func saveScan(started time.Time, targets []ui.Target) error {
+	db, err := openStore()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	id, err := db.SaveScan(context.Background(), started, time.Now(), rootCmd.Version, targets)
+	if err != nil {
+		return fmt.Errorf("failed to save scan: %w", err)
+	}
+	if cfg.Verbose && !cfg.Quiet {
+		fmt.Fprintf(os.Stderr, "Saved scan %d to %s\n", id, dbPath())
 	}
+	return nil
+}
+
+// scanTarget crawls a single target, streaming its findings as they are found
+func scanTarget(ctx context.Context, c *crawler.Crawler, d *tech.Detector, outputter *ui.Outputter, targetURL string) ui.Target {
+	target := ui.Target{URL: targetURL}
 
 	// Findings channel
 	findingsChan := make(chan scanner.Finding)
 
-	// Start scanning in a goroutine
+	// Crawl (or scan the single page) in a goroutine
+	var result *crawler.Result
+	var crawlErr error
 	go func() {
 		defer close(findingsChan)
-		s := scanner.NewScanner(cfg.IncludeEntropy, cfg.MinEntropy, cfg.MinLength)
-		s.ScanStream(result, findingsChan)
+		result, crawlErr = c.Crawl(ctx, targetURL, findingsChan)
 	}()
 
-	// Stream results
-	// We need to intercept the stream to write to file as well
-
-	// Print header for file
-	if fileOutputter != nil && !cfg.JSONOutput {
-		fmt.Fprintf(file, "Webhog Scan Results\n")
-		fmt.Fprintf(file, "===================\n\n")
-	}
-
 	// Since ui.StreamOutput sucks the channel dry, we can't easily tee it
 	// without modifying UI or manually consuming here.
 	// Manual consumption is safer to control both outputs.
+	target.Findings = outputter.StreamOutput(os.Stdout, findingsChan)
 
-	displayFindings := outputter.StreamOutput(os.Stdout, findingsChan)
-
-	// After streaming finishes, we have all findings in displayFindings.
-	// Note: StreamOutput already handles deduplication internally for display options.
-	// For the file, we can just dump displayFindings at the end if we don't care about
-	// strict streaming *to the file*. Streaming to file is nice but dumping at end is easier.
-	// Wait, user asked: "write output ... while also maintaining current printing output functionality"
-	//
-	// Writing to file at the end is fine.
-
-	if fileOutputter != nil {
-		// Re-output everything to file
-		if cfg.JSONOutput {
-			fileOutputter.Output(file, displayFindings, result, technologies)
-		} else {
-			fileOutputter.Output(file, displayFindings, result, technologies)
+	if crawlErr != nil {
+		target.Error = crawlErr.Error()
+		if cfg.Verbose && !cfg.Quiet {
+			fmt.Fprintf(os.Stderr, "%s\n", target.Error)
 		}
+		return target
 	}
+	target.Crawl = result
 
-	if cfg.JSONOutput {
-		return outputter.Output(os.Stdout, displayFindings, result, technologies)
+	if cfg.Verbose && !cfg.Quiet {
+		fmt.Fprintf(os.Stderr, "Scanned %d pages, %d JS blobs\n", len(result.Pages), result.JSBlobs())
 	}
 
-	// For normal output, print the summary box at the end
-	if !cfg.Quiet {
-		outputter.PrintSummary(os.Stdout, displayFindings, result, technologies)
+	// Detect technologies
+	if d != nil {
+		target.Technologies = d.Analyze(result.Root.Headers, []byte(result.Root.HTML))
 	}
 
-	return nil
+	return target
 }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// collectTargets gathers target URLs from positional arguments, the --list
+// file and stdin. Stdin is read when "-" is given as an argument, or when no
+// other targets were supplied and input is being piped in.
+func collectTargets(args []string, listFile string) ([]string, error) {
+	var targets []string
+	readStdin := false
+
+	for _, arg := range args {
+		if arg == "-" {
+			readStdin = true
+			continue
+		}
+		targets = append(targets, arg)
+	}
+
+	if listFile != "" {
+		f, err := os.Open(listFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open target list: %w", err)
+		}
+		defer f.Close()
+
+		urls, err := readTargets(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read target list: %w", err)
+		}
+		targets = append(targets, urls...)
+	}
+
+	if !readStdin && len(targets) == 0 && stdinIsPiped() {
+		readStdin = true
+	}
+
+	if readStdin {
+		urls, err := readTargets(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read targets from stdin: %w", err)
+		}
+		targets = append(targets, urls...)
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no targets given: pass URLs as arguments, with --list, or on stdin")
+	}
+
+	return dedupeTargets(targets), nil
+}
+
+// readTargets reads newline-delimited URLs, skipping blank lines and # comments
+func readTargets(r io.Reader) ([]string, error) {
+	var targets []string
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		targets = append(targets, line)
+	}
+
+	return targets, sc.Err()
+}
+
+// dedupeTargets removes repeated URLs while preserving order
+func dedupeTargets(targets []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+
+	for _, t := range targets {
+		if !seen[t] {
+			seen[t] = true
+			unique = append(unique, t)
+		}
+	}
+
+	return unique
+}
+
+// stdinIsPiped reports whether stdin is a pipe or file rather than a terminal
+func stdinIsPiped() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice == 0
+}
@@ -8,27 +8,52 @@ type Config struct {
 	Verbose    bool
 	NoColor    bool
 	ConfigFile string
+	DBPath     string
 
 	// Scan flags
+	TargetsFile    string
 	Headless       bool
+	Tabs           int
 	Timeout        time.Duration
 	MaxDepth       int
 	SameDomain     bool
+	Concurrency    int
+	Format         string
 	JSONOutput     bool
 	Quiet          bool
 	PlainOutput    bool
 	OutputFile     string
+	Redact         string
+	Baseline       string
+	FailOn         []string
+	IgnoreFiles    []string
+	RulesFiles     []string
 	IncludeEntropy bool
 	MinEntropy     float64
 	MinLength      int
+	MinSeverity    string
+	DecodeDepth    int
+	Beautify       bool
+
+	// Database flags
+	Save bool
+
+	// Verification flags
+	Verify          bool
+	VerifyEndpoints map[string]string
 }
 
 // NewConfig returns a Config with sensible defaults
 func NewConfig() *Config {
 	return &Config{
-		Timeout:    30 * time.Second,
-		MaxDepth:   0,
-		MinEntropy: 4.5,
-		MinLength:  20,
+		Timeout:     30 * time.Second,
+		Tabs:        4,
+		Format:      "text",
+		MaxDepth:    0,
+		Concurrency: 10,
+		MinEntropy:  4.5,
+		MinLength:   20,
+		MinSeverity: "info",
+		DecodeDepth: 2,
 	}
 }
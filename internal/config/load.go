@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of environment variables overriding settings,
+// e.g. WEBHOG_MAX_DEPTH=2
+const EnvPrefix = "WEBHOG_"
+
+// Values holds settings read from a config file or the environment, keyed
+// by the flag they set (e.g. "max-depth"). Lists are comma-separated and
+// maps are written as comma-separated key=value pairs, matching the syntax
+// the flags accept.
+type Values map[string]string
+
+// ignoredKeys are config file sections consumed elsewhere (the rules
+// loader), spelled exactly as it reads them
+var ignoredKeys = map[string]bool{
+	"rules":           true,
+	"replace_builtin": true,
+}
+
+// DefaultPath returns the default config file location,
+// ~/.config/webhog/config.yaml (or under $XDG_CONFIG_HOME when set)
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "webhog", "config.yaml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "webhog", "config.yaml")
+}
+
+// LoadFile reads settings from a YAML or TOML config file. The format is
+// chosen by extension; anything other than .toml is parsed as YAML.
+func LoadFile(path string) (Values, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	raw := make(map[string]interface{})
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		err = toml.Unmarshal(data, &raw)
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	values := make(Values, len(raw))
+	for key, value := range raw {
+		if ignoredKeys[key] {
+			continue
+		}
+		name := normalizeKey(key)
+
+		s, err := formatValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", path, key, err)
+		}
+		values[name] = s
+	}
+
+	return values, nil
+}
+
+// FromEnv collects WEBHOG_* settings from an environment in os.Environ form
+func FromEnv(environ []string) Values {
+	values := make(Values)
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, EnvPrefix) {
+			continue
+		}
+		values[normalizeKey(strings.TrimPrefix(key, EnvPrefix))] = value
+	}
+	return values
+}
+
+// EnvName returns the environment variable that overrides a setting
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+// normalizeKey maps config keys like "max_depth" or "MAX_DEPTH" to flag names
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(key), "_", "-"))
+}
+
+// formatValue renders a decoded config value in flag syntax
+func formatValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			s, err := formatValue(item)
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, s)
+		}
+		return strings.Join(parts, ","), nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		parts := make([]string, 0, len(v))
+		for _, k := range keys {
+			s, err := formatValue(v[k])
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, k+"="+s)
+		}
+		return strings.Join(parts, ","), nil
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	}
+	return "", fmt.Errorf("unsupported value %v", value)
+}
@@ -0,0 +1,237 @@
+package crawler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/user/webhog/internal/renderer"
+	"github.com/user/webhog/internal/scanner"
+)
+
+// Page records the outcome of rendering and scanning a single crawled URL
+type Page struct {
+	URL     string `json:"url"`
+	Depth   int    `json:"depth"`
+	JSBlobs int    `json:"js_blobs"`
+	Error   string `json:"error,omitempty"`
+	Blobs   []Blob `json:"blobs,omitempty"` // The scanned blobs, for the findings database and per-page reports
+}
+
+// Blob identifies a scanned JS blob by the hash of its content
+type Blob struct {
+	Source string `json:"source"`
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int    `json:"size"`
+}
+
+// Result summarizes a crawl starting from a single URL
+type Result struct {
+	URL   string                 // The final URL of the start page
+	Root  *renderer.RenderResult // The rendered start page
+	Pages []Page                 // Every page visited, in crawl order
+}
+
+// JSBlobs returns the total number of JS blobs scanned across all pages
+func (r *Result) JSBlobs() int {
+	total := 0
+	for _, p := range r.Pages {
+		total += p.JSBlobs
+	}
+	return total
+}
+
+// Crawler walks an application breadth-first, scanning every page it renders
+type Crawler struct {
+	renderer    renderer.Renderer
+	scanner     *scanner.Scanner
+	maxDepth    int
+	sameDomain  bool
+	concurrency int
+	onPage      func(Page)
+
+	mu sync.Mutex // guards result pages and the onPage callback
+}
+
+// NewCrawler creates a crawler that follows links up to maxDepth hops from
+// the start URL, rendering up to concurrency pages at once. A maxDepth of 0
+// scans the start URL only.
+func NewCrawler(r renderer.Renderer, s *scanner.Scanner, maxDepth int, sameDomain bool, concurrency int) *Crawler {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Crawler{
+		renderer:    r,
+		scanner:     s,
+		maxDepth:    maxDepth,
+		sameDomain:  sameDomain,
+		concurrency: concurrency,
+	}
+}
+
+// OnPage registers a callback invoked after each page has been processed.
+// Calls are serialized, so the callback does not need its own locking.
+func (c *Crawler) OnPage(fn func(Page)) {
+	c.onPage = fn
+}
+
+// Crawl renders startURL, follows discovered links breadth-first and sends
+// all findings to findingsChan. An error is returned only if the start page
+// cannot be rendered; failures on later pages are recorded in Result.Pages.
+func (c *Crawler) Crawl(ctx context.Context, startURL string, findingsChan chan<- scanner.Finding) (*Result, error) {
+	root, err := c.render(ctx, startURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		URL:  root.URL,
+		Root: root,
+	}
+
+	visited := newVisitedSet()
+	visited.add(startURL)
+	visited.add(root.URL)
+
+	rootHost := hostOf(root.URL)
+	frontier := c.process(ctx, root, 0, result, findingsChan)
+
+	for depth := 1; depth <= c.maxDepth && len(frontier) > 0; depth++ {
+		var level []string
+		for _, link := range frontier {
+			if c.sameDomain && hostOf(link) != rootHost {
+				continue
+			}
+			if visited.add(link) {
+				level = append(level, link)
+			}
+		}
+
+		var next []string
+		var nextMu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, c.concurrency)
+
+		for _, link := range level {
+			if ctx.Err() != nil {
+				break
+			}
+
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(link string, depth int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				page, err := c.render(ctx, link)
+				if err != nil {
+					c.record(result, Page{URL: link, Depth: depth, Error: err.Error()})
+					return
+				}
+
+				// Skip pages that redirected somewhere we have already been
+				if page.URL != link && !visited.add(page.URL) {
+					return
+				}
+
+				links := c.process(ctx, page, depth, result, findingsChan)
+				nextMu.Lock()
+				next = append(next, links...)
+				nextMu.Unlock()
+			}(link, depth)
+		}
+
+		wg.Wait()
+		frontier = next
+	}
+
+	return result, nil
+}
+
+// render renders a single URL. The renderer applies the per-page timeout,
+// so that it can tell a slow page from a cancelled scan.
+func (c *Crawler) render(ctx context.Context, targetURL string) (*renderer.RenderResult, error) {
+	page, err := c.renderer.Render(ctx, targetURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", targetURL, err)
+	}
+	return page, nil
+}
+
+// process scans a rendered page and returns the links to follow from it
+func (c *Crawler) process(ctx context.Context, page *renderer.RenderResult, depth int, result *Result, findingsChan chan<- scanner.Finding) []string {
+	c.scanner.ScanStream(ctx, page, findingsChan)
+	c.record(result, Page{URL: page.URL, Depth: depth, JSBlobs: len(page.JSBlobs), Blobs: hashBlobs(page.JSBlobs)})
+
+	if depth >= c.maxDepth {
+		return nil
+	}
+	return ExtractLinks(page)
+}
+
+// hashBlobs identifies each blob by the SHA-256 of its body
+func hashBlobs(blobs []renderer.JSBlob) []Blob {
+	hashed := make([]Blob, len(blobs))
+	for i, b := range blobs {
+		sum := sha256.Sum256([]byte(b.Body))
+		hashed[i] = Blob{
+			Source: b.Source,
+			Path:   b.Path,
+			SHA256: hex.EncodeToString(sum[:]),
+			Size:   len(b.Body),
+		}
+	}
+	return hashed
+}
+
+// record appends a page to the result and notifies the OnPage callback
+func (c *Crawler) record(result *Result, p Page) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result.Pages = append(result.Pages, p)
+	if c.onPage != nil {
+		c.onPage(p)
+	}
+}
+
+// visitedSet is a concurrency-safe set of normalized URLs
+type visitedSet struct {
+	mu   sync.Mutex
+	urls map[string]bool
+}
+
+func newVisitedSet() *visitedSet {
+	return &visitedSet{urls: make(map[string]bool)}
+}
+
+// add marks a URL as visited and reports whether it was new
+func (v *visitedSet) add(rawURL string) bool {
+	key := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		key = normalizeURL(u)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.urls[key] {
+		return false
+	}
+	v.urls[key] = true
+	return true
+}
+
+// hostOf returns the lowercased hostname of a URL
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
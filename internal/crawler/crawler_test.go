@@ -0,0 +1,45 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/user/webhog/internal/pool"
+	"github.com/user/webhog/internal/renderer"
+	"github.com/user/webhog/internal/scanner"
+)
+
+func TestSlowPageTimesOut(t *testing.T) {
+	if _, found := launcher.LookPath(); !found {
+		t.Skip("no Chromium installed")
+	}
+
+	// The page never finishes loading
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	r := renderer.NewHeadlessRenderer(time.Second, pool.New(2), 1)
+	defer r.Close()
+	c := NewCrawler(r, scanner.NewScanner(false, 4.5, 20), 0, true, 1)
+
+	_, err := c.Crawl(context.Background(), srv.URL, make(chan scanner.Finding, 16))
+	var timeout *renderer.NavigationTimeoutError
+	if !errors.As(err, &timeout) {
+		t.Fatalf("got error %v, want a navigation timeout", err)
+	}
+	if timeout.URL != srv.URL {
+		t.Errorf("timeout reported for %s, want %s", timeout.URL, srv.URL)
+	}
+}
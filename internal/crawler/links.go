@@ -0,0 +1,138 @@
+package crawler
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/user/webhog/internal/renderer"
+	"golang.org/x/net/html"
+)
+
+// routeRe matches quoted absolute paths in JavaScript, e.g. router definitions
+// like path: "/settings/profile" or fetch("/account")
+var routeRe = regexp.MustCompile(`["'](/[a-zA-Z0-9_\-][a-zA-Z0-9_\-./]*)["']`)
+
+// linkAttrs lists the element attributes that may point at another page
+var linkAttrs = map[string]string{
+	"a":      "href",
+	"area":   "href",
+	"link":   "href",
+	"form":   "action",
+	"iframe": "src",
+	"frame":  "src",
+}
+
+// skipExtensions are static assets that are never worth rendering as a page
+var skipExtensions = map[string]bool{
+	".css": true, ".js": true, ".mjs": true, ".map": true, ".json": true,
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true,
+	".ico": true, ".webp": true, ".avif": true, ".bmp": true,
+	".woff": true, ".woff2": true, ".ttf": true, ".otf": true, ".eot": true,
+	".mp4": true, ".webm": true, ".mp3": true, ".wav": true, ".ogg": true,
+	".pdf": true, ".zip": true, ".gz": true, ".tar": true, ".exe": true,
+	".dmg": true, ".xml": true, ".txt": true,
+}
+
+// ExtractLinks returns the absolute, normalized URLs of all pages referenced
+// by a rendered page: anchors, forms, <link> and frame targets in the HTML,
+// plus routes discovered in its JavaScript.
+func ExtractLinks(result *renderer.RenderResult) []string {
+	seen := make(map[string]bool)
+	var links []string
+
+	add := func(raw string) {
+		link, ok := normalizeLink(result.URL, raw)
+		if !ok || seen[link] {
+			return
+		}
+		seen[link] = true
+		links = append(links, link)
+	}
+
+	if doc, err := html.Parse(strings.NewReader(result.HTML)); err == nil {
+		var traverse func(*html.Node)
+		traverse = func(n *html.Node) {
+			if n.Type == html.ElementNode {
+				if attr, ok := linkAttrs[n.Data]; ok {
+					if val := getAttr(n, attr); val != "" {
+						add(val)
+					}
+				}
+			}
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				traverse(c)
+			}
+		}
+		traverse(doc)
+	}
+
+	for _, blob := range result.JSBlobs {
+		for _, match := range routeRe.FindAllStringSubmatch(blob.Body, -1) {
+			if !strings.HasPrefix(match[1], "//") {
+				add(match[1])
+			}
+		}
+	}
+
+	return links
+}
+
+// normalizeLink resolves a link against the page URL and normalizes it so
+// that equivalent URLs compare equal. It reports false for links that should
+// not be crawled (non-HTTP schemes, static assets).
+func normalizeLink(baseURL, raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.HasPrefix(raw, "#") {
+		return "", false
+	}
+
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", false
+	}
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+
+	u := base.ResolveReference(ref)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", false
+	}
+	if skipExtensions[strings.ToLower(path.Ext(u.Path))] {
+		return "", false
+	}
+
+	return normalizeURL(u), true
+}
+
+// normalizeURL lowercases the scheme and host, drops default ports and
+// fragments, and ensures a non-empty path
+func normalizeURL(u *url.URL) string {
+	n := *u
+	n.Scheme = strings.ToLower(n.Scheme)
+	n.Host = strings.ToLower(n.Host)
+	n.Fragment = ""
+	n.RawFragment = ""
+
+	if port := n.Port(); (n.Scheme == "http" && port == "80") || (n.Scheme == "https" && port == "443") {
+		n.Host = n.Hostname()
+	}
+	if n.Path == "" {
+		n.Path = "/"
+	}
+
+	return n.String()
+}
+
+// getAttr returns the value of an attribute from an HTML node
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package pool
+
+import (
+	"context"
+	"sync"
+)
+
+// Pool bounds how many tasks run at once. A single Pool is shared by the
+// renderers and the scanner so the total load on a target stays capped no
+// matter how many pages or scripts are in flight.
+//
+// Tasks must not acquire a slot from within another task on the same Pool,
+// as that can deadlock once every slot is held by a waiting parent.
+type Pool struct {
+	sem chan struct{}
+}
+
+// New creates a pool allowing at most size concurrent tasks
+func New(size int) *Pool {
+	if size < 1 {
+		size = 1
+	}
+	return &Pool{
+		sem: make(chan struct{}, size),
+	}
+}
+
+// Size returns the maximum number of concurrent tasks
+func (p *Pool) Size() int {
+	if p == nil {
+		return 1
+	}
+	return cap(p.sem)
+}
+
+// Do waits for a free slot and runs fn in the calling goroutine. It returns
+// the context error without running fn if ctx is cancelled first. A nil Pool
+// runs fn immediately.
+func (p *Pool) Do(ctx context.Context, fn func()) error {
+	if p == nil {
+		fn()
+		return nil
+	}
+
+	select {
+	case p.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-p.sem }()
+
+	fn()
+	return nil
+}
+
+// Each runs fn(i) for i in [0, n) concurrently, each call holding a slot,
+// and waits for all of them to finish. A nil Pool runs them sequentially.
+func (p *Pool) Each(ctx context.Context, n int, fn func(i int)) {
+	if p == nil {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p.Do(ctx, func() { fn(i) })
+		}(i)
+	}
+	wg.Wait()
+}
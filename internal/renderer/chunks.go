@@ -0,0 +1,377 @@
+package renderer
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxChunks caps how many lazily loaded chunks are fetched per page
+const maxChunks = 500
+
+var (
+	// webpack 5: __webpack_require__.u = e => "static/js/" + e + "." + {...}[e] + ".chunk.js"
+	// and the function form: .u = function(e) { return ... }
+	webpackChunkFnRe = regexp.MustCompile(`\.u\s*=\s*(?:function\s*\(\s*([\w$]+)\s*\)\s*\{\s*return\b|\(?\s*([\w$]+)\s*\)?\s*=>\s*(?:\{\s*return\b)?)`)
+
+	// webpack 4: function jsonpScriptSrc(e) { return o.p + "static/js/" + ... }
+	webpackJsonpSrcRe = regexp.MustCompile(`function\s*[\w$]*\s*\(\s*([\w$]+)\s*\)\s*\{\s*return\s+[\w$]+\.p\s*\+`)
+
+	// __webpack_require__.p = "/assets/"
+	webpackPublicPathRe = regexp.MustCompile(`[\w$]+\.p\s*=\s*["']([^"']*)["']`)
+
+	// Vite: const __vite__mapDeps = (i, m = __vite__mapDeps, d = (m.f || (m.f = ["assets/About-abc.js", ...]))) => ...
+	viteMapDepsRe = regexp.MustCompile(`m\.f\s*=\s*\[([^\]]*)\]`)
+
+	// Native dynamic imports left in ES module bundles: import("./About-abc.js")
+	dynamicImportRe = regexp.MustCompile(`\bimport\(\s*["']([^"']+\.m?js)["']\s*\)`)
+
+	stringLiteralRe = regexp.MustCompile(`"([^"\\]*)"|'([^'\\]*)'`)
+	objectEntryRe   = regexp.MustCompile(`(?:"([^"]+)"|'([^']+)'|([\w$]+))\s*:\s*(?:"([^"]*)"|'([^']*)')`)
+	identifierRe    = regexp.MustCompile(`^[\w$]+$`)
+	publicPathRefRe = regexp.MustCompile(`^[\w$]+\.p$`)
+)
+
+// enumerateChunks finds the lazily loaded chunks referenced by webpack
+// runtimes and Vite bundles in the blobs, fetches them, and repeats for the
+// chunks it found until no new ones turn up
+func (f *fetcher) enumerateChunks(ctx context.Context, pageURL string, blobs []JSBlob) []JSBlob {
+	seen := make(map[string]bool)
+	for _, blob := range blobs {
+		seen[blob.Path] = true
+	}
+
+	var chunks []JSBlob
+	pending := blobs
+	for len(pending) > 0 && len(seen) < maxChunks && ctx.Err() == nil {
+		var urls []string
+		for _, blob := range pending {
+			for _, u := range chunkURLs(pageURL, blob) {
+				if !seen[u] && len(seen) < maxChunks {
+					seen[u] = true
+					urls = append(urls, u)
+				}
+			}
+		}
+
+		pending = nil
+		for i, res := range f.fetchAll(ctx, urls) {
+			if res.Err != nil {
+				continue
+			}
+			blob := JSBlob{
+				Source:    "chunk",
+				Path:      urls[i],
+				Body:      res.Resp.Body,
+				SourceMap: sourceMapHeader(res.Resp.Header),
+			}
+			chunks = append(chunks, blob)
+			pending = append(pending, blob)
+		}
+	}
+
+	return chunks
+}
+
+// chunkURLs returns the absolute URLs of all chunks a blob can load
+func chunkURLs(pageURL string, blob JSBlob) []string {
+	base := blob.Path
+	if blob.Source == "inline" {
+		base = pageURL
+	}
+
+	var urls []string
+	add := func(ref string) {
+		if u, ok := resolveChunk(base, ref); ok {
+			urls = append(urls, u)
+		}
+	}
+
+	// webpack runtime chunk maps
+	publicPath := ""
+	if m := webpackPublicPathRe.FindStringSubmatch(blob.Body); m != nil && m[1] != "auto" {
+		publicPath = m[1]
+	}
+	for _, chunk := range webpackChunks(blob.Body) {
+		if publicPath != "" {
+			add(strings.TrimSuffix(publicPath, "/") + "/" + strings.TrimPrefix(chunk, "/"))
+		} else {
+			add(chunk)
+		}
+	}
+
+	// Vite dependency map, relative to the base
+	for _, m := range viteMapDepsRe.FindAllStringSubmatch(blob.Body, -1) {
+		for _, lit := range stringLiteralRe.FindAllStringSubmatch(m[1], -1) {
+			if ref := lit[1] + lit[2]; strings.HasSuffix(ref, ".js") {
+				add(ref)
+			}
+		}
+	}
+
+	// Dynamic imports, relative to the importing module
+	for _, m := range dynamicImportRe.FindAllStringSubmatch(blob.Body, -1) {
+		if u, err := resolveURL(base, m[1]); err == nil {
+			urls = append(urls, u)
+		}
+	}
+
+	return urls
+}
+
+// resolveChunk resolves a bundler-relative chunk path. Bundlers emit paths
+// relative to the output root (e.g. "static/js/1.abc.chunk.js"), so when the
+// loading script lives inside that same directory the shared suffix is
+// stripped before resolving.
+func resolveChunk(base, ref string) (string, bool) {
+	if strings.HasPrefix(ref, "/") || strings.Contains(ref, "://") {
+		u, err := resolveURL(base, ref)
+		return u, err == nil
+	}
+
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", false
+	}
+
+	dir := path.Dir(b.Path) + "/"
+	if refDir := path.Dir(ref); refDir != "." {
+		if suffix := refDir + "/"; strings.HasSuffix(dir, "/"+suffix) || dir == suffix {
+			dir = strings.TrimSuffix(dir, suffix)
+		}
+	}
+
+	u, err := resolveURL(b.ResolveReference(&url.URL{Path: dir}).String(), ref)
+	return u, err == nil
+}
+
+// webpackChunks reconstructs every chunk path from a webpack runtime's
+// chunk filename function
+func webpackChunks(body string) []string {
+	var chunks []string
+
+	for _, re := range []*regexp.Regexp{webpackChunkFnRe, webpackJsonpSrcRe} {
+		for _, loc := range re.FindAllStringSubmatchIndex(body, -1) {
+			param := ""
+			for g := 1; g*2 < len(loc); g++ {
+				if loc[g*2] != -1 {
+					param = body[loc[g*2]:loc[g*2+1]]
+					break
+				}
+			}
+			if param == "" {
+				continue
+			}
+
+			expr := takeExpression(body[loc[1]:])
+			chunks = append(chunks, evaluateChunkExpr(expr, param)...)
+		}
+	}
+
+	return chunks
+}
+
+// takeExpression returns the JavaScript expression at the start of s, up to
+// the first top-level terminator
+func takeExpression(s string) string {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\'', '`':
+			i = skipString(s, i)
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			if depth == 0 {
+				return s[:i]
+			}
+			depth--
+		case ';', ',', '\n':
+			if depth == 0 {
+				return s[:i]
+			}
+		}
+	}
+	return s
+}
+
+// skipString returns the index of the quote closing the string starting at i
+func skipString(s string, i int) int {
+	quote := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case quote:
+			return j
+		}
+	}
+	return len(s)
+}
+
+// splitTopLevel splits an expression on a separator that is not nested in
+// brackets or strings
+func splitTopLevel(expr, sep string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; c {
+		case '"', '\'', '`':
+			i = skipString(expr, i)
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		default:
+			if depth == 0 && strings.HasPrefix(expr[i:], sep) {
+				parts = append(parts, expr[start:i])
+				i += len(sep) - 1
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+// isParenthesized reports whether the whole expression is wrapped in one
+// pair of parentheses, as in "(a||b)" but not "(a)+(b)"
+func isParenthesized(expr string) bool {
+	if !strings.HasPrefix(expr, "(") || !strings.HasSuffix(expr, ")") {
+		return false
+	}
+	depth := 0
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '"', '\'', '`':
+			i = skipString(expr, i)
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 && i != len(expr)-1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// chunkTerm is one operand of the chunk filename concatenation
+type chunkTerm struct {
+	literal  string
+	isID     bool
+	lookup   map[string]string // chunk id -> value
+	fallback *chunkTerm        // used when lookup has no entry
+}
+
+// evaluateChunkExpr evaluates a concatenation such as
+// "static/js/" + ({1:"about"}[e] || e) + "." + {1:"abc123"}[e] + ".chunk.js"
+// for every chunk id that appears in its lookup tables
+func evaluateChunkExpr(expr, param string) []string {
+	var terms []*chunkTerm
+	ids := make(map[string]bool)
+
+	for _, part := range splitTopLevel(expr, "+") {
+		term := parseChunkTerm(part, param)
+		if term == nil {
+			return nil
+		}
+		for t := term; t != nil; t = t.fallback {
+			for id := range t.lookup {
+				ids[id] = true
+			}
+		}
+		terms = append(terms, term)
+	}
+
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	var chunks []string
+	for _, id := range sorted {
+		var b strings.Builder
+		ok := true
+		for _, term := range terms {
+			value, found := term.eval(id)
+			if !found {
+				ok = false
+				break
+			}
+			b.WriteString(value)
+		}
+		if ok {
+			chunks = append(chunks, b.String())
+		}
+	}
+
+	return chunks
+}
+
+// eval returns the term's value for a chunk id
+func (t *chunkTerm) eval(id string) (string, bool) {
+	switch {
+	case t.isID:
+		return id, true
+	case t.lookup != nil:
+		if v, ok := t.lookup[id]; ok {
+			return v, true
+		}
+		if t.fallback != nil {
+			return t.fallback.eval(id)
+		}
+		return "", false
+	}
+	return t.literal, true
+}
+
+// parseChunkTerm parses a single operand, returning nil if it is something
+// we cannot evaluate statically
+func parseChunkTerm(part, param string) *chunkTerm {
+	part = strings.TrimSpace(part)
+	for isParenthesized(part) {
+		part = strings.TrimSpace(part[1 : len(part)-1])
+	}
+
+	if alts := splitTopLevel(part, "||"); len(alts) > 1 {
+		term := parseChunkTerm(alts[0], param)
+		if term == nil {
+			return nil
+		}
+		term.fallback = parseChunkTerm(strings.Join(alts[1:], "||"), param)
+		return term
+	}
+
+	switch {
+	case part == param:
+		return &chunkTerm{isID: true}
+	case publicPathRefRe.MatchString(part):
+		// The public path is applied when resolving
+		return &chunkTerm{}
+	case identifierRe.MatchString(part):
+		return nil
+	}
+
+	if lit := stringLiteralRe.FindStringSubmatch(part); lit != nil && lit[0] == part {
+		return &chunkTerm{literal: lit[1] + lit[2]}
+	}
+	if strings.HasPrefix(part, "`") && strings.HasSuffix(part, "`") && !strings.Contains(part, "${") {
+		return &chunkTerm{literal: part[1 : len(part)-1]}
+	}
+
+	// {1:"abc",2:"def"}[e]
+	if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "["+param+"]") {
+		lookup := make(map[string]string)
+		for _, m := range objectEntryRe.FindAllStringSubmatch(part[:len(part)-len(param)-2], -1) {
+			lookup[m[1]+m[2]+m[3]] = m[4] + m[5]
+		}
+		return &chunkTerm{lookup: lookup}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package renderer
+
+import (
+	"fmt"
+	"time"
+)
+
+// LaunchError reports that the headless browser could not be started or
+// connected to. Every later render will fail the same way.
+type LaunchError struct {
+	Err error
+}
+
+func (e *LaunchError) Error() string {
+	return fmt.Sprintf("launching browser: %v", e.Err)
+}
+
+func (e *LaunchError) Unwrap() error {
+	return e.Err
+}
+
+// NavigationTimeoutError reports that a page did not finish loading within
+// the render timeout
+type NavigationTimeoutError struct {
+	URL     string
+	Timeout time.Duration
+}
+
+func (e *NavigationTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s loading %s", e.Timeout, e.URL)
+}
+
+// TargetCrashedError reports that the browser tab crashed while rendering
+type TargetCrashedError struct {
+	URL string
+}
+
+func (e *TargetCrashedError) Error() string {
+	return fmt.Sprintf("browser tab crashed while rendering %s", e.URL)
+}
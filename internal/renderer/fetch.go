@@ -0,0 +1,93 @@
+package renderer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/user/webhog/internal/pool"
+)
+
+const userAgent = "webhog/0.1.0 (https://github.com/user/webhog)"
+
+// fetcher performs the HTTP requests shared by both renderers. Every request
+// holds a slot in the shared worker pool while it is in flight.
+type fetcher struct {
+	client *http.Client
+	pool   *pool.Pool
+}
+
+// response is a successfully fetched HTTP resource
+type response struct {
+	URL    string      // The final URL (after redirects)
+	Header http.Header // Response headers
+	Body   string      // Response body
+}
+
+// fetchResult pairs a response with the error that prevented it
+type fetchResult struct {
+	Resp *response
+	Err  error
+}
+
+// fetch GETs a URL, treating non-200 responses as errors
+func (f *fetcher) fetch(ctx context.Context, targetURL string) (*response, error) {
+	var resp *response
+	var err error
+
+	if perr := f.pool.Do(ctx, func() {
+		resp, err = f.do(ctx, targetURL)
+	}); perr != nil {
+		return nil, perr
+	}
+
+	return resp, err
+}
+
+// fetchAll GETs the URLs concurrently and returns the results in input order
+func (f *fetcher) fetchAll(ctx context.Context, urls []string) []fetchResult {
+	results := make([]fetchResult, len(urls))
+	for i := range results {
+		// Overwritten below unless ctx is cancelled before the fetch starts
+		results[i].Err = context.Canceled
+	}
+
+	f.pool.Each(ctx, len(urls), func(i int) {
+		resp, err := f.do(ctx, urls[i])
+		results[i] = fetchResult{Resp: resp, Err: err}
+	})
+
+	return results
+}
+
+// do performs the request without touching the pool
+func (f *fetcher) do(ctx context.Context, targetURL string) (*response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", targetURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	return &response{
+		URL:    resp.Request.URL.String(),
+		Header: resp.Header,
+		Body:   string(body),
+	}, nil
+}
@@ -2,33 +2,93 @@ package renderer
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/user/webhog/internal/pool"
 )
 
-// HeadlessRenderer uses a headless browser (rod) to render pages
+// HeadlessRenderer uses a headless browser (rod) to render pages. A single
+// browser process is launched on first use and shared by every render; each
+// page gets its own incognito context so cookies and storage never leak
+// between pages.
 type HeadlessRenderer struct {
 	timeout time.Duration
+	pool    *pool.Pool
+	fetcher *fetcher
+	tabs    chan struct{} // bounds the number of open tabs
+
+	mu        sync.Mutex
+	launcher  *launcher.Launcher
+	browser   *rod.Browser
+	launchErr error
+	closed    bool
 }
 
-// NewHeadlessRenderer creates a new headless renderer
-func NewHeadlessRenderer(timeout time.Duration) *HeadlessRenderer {
+// NewHeadlessRenderer creates a new headless renderer with at most tabs pages
+// open at once. Page loads and script fetches are bounded by the given
+// worker pool. Call Close to shut the browser down.
+func NewHeadlessRenderer(timeout time.Duration, p *pool.Pool, tabs int) *HeadlessRenderer {
+	if tabs < 1 {
+		tabs = 1
+	}
 	return &HeadlessRenderer{
 		timeout: timeout,
+		pool:    p,
+		fetcher: &fetcher{
+			client: &http.Client{
+				Timeout: 10 * time.Second,
+			},
+			pool: p,
+		},
+		tabs: make(chan struct{}, tabs),
 	}
 }
 
-// Render uses a headless browser to render the page and extract JavaScript
-func (h *HeadlessRenderer) Render(ctx context.Context, targetURL string) (*RenderResult, error) {
+// Close shuts down the shared browser, if it was started
+func (h *HeadlessRenderer) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	if h.browser == nil {
+		return nil
+	}
+
+	err := h.browser.Close()
+	h.launcher.Cleanup()
+	h.browser = nil
+	h.launcher = nil
+	return err
+}
+
+// getBrowser returns the shared browser, launching it on first use. The
+// launch is abandoned if ctx is done first.
+func (h *HeadlessRenderer) getBrowser(ctx context.Context) (*rod.Browser, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, fmt.Errorf("renderer is closed")
+	}
+	if h.browser != nil {
+		return h.browser, nil
+	}
+	if h.launchErr != nil {
+		return nil, h.launchErr
+	}
+
 	// Launch browser with auto-download support
-	l := launcher.New()
+	l := launcher.New().Context(ctx)
 
 	// Check if a browser is already installed
 	path, found := launcher.LookPath()
@@ -39,54 +99,237 @@ func (h *HeadlessRenderer) Render(ctx context.Context, targetURL string) (*Rende
 		l = l.Bin(path)
 	}
 
-	// Launch headless browser
-	controlURL := l.Headless(true).MustLaunch()
-	browser := rod.New().ControlURL(controlURL).MustConnect()
-	defer browser.MustClose()
+	// Launch headless browser, remembering a failure so every later render
+	// doesn't retry the launch. A cancelled launch is left for the next
+	// render to retry.
+	controlURL, err := l.Headless(true).Launch()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		h.launchErr = &LaunchError{Err: err}
+		return nil, h.launchErr
+	}
+
+	browser := rod.New().ControlURL(controlURL)
+	if err := browser.Connect(); err != nil {
+		l.Kill()
+		h.launchErr = &LaunchError{Err: err}
+		return nil, h.launchErr
+	}
+
+	h.browser = browser
+	h.launcher = l
+
+	return h.browser, nil
+}
+
+// pageContent is what is pulled out of the browser while the page is open
+type pageContent struct {
+	url       string
+	html      string
+	scripts   []JSBlob // inline scripts, plus external ones without a body yet
+	responses []NetworkResponse
+}
+
+// Render uses a headless browser to render the page and extract JavaScript
+func (h *HeadlessRenderer) Render(ctx context.Context, targetURL string) (*RenderResult, error) {
+	var content *pageContent
+	var err error
+
+	// Wait for a free tab before taking a pool slot, so pages queued for a
+	// tab don't starve the rest of the pool
+	select {
+	case h.tabs <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	// Hold a pool slot only while the browser is busy; external scripts are
+	// fetched afterwards so they can take their own slots
+	perr := h.pool.Do(ctx, func() {
+		content, err = h.load(ctx, targetURL)
+	})
+	<-h.tabs
+	if perr != nil {
+		return nil, perr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Scripts the browser already downloaded don't need fetching again
+	captured := make(map[string]NetworkResponse)
+	for _, r := range content.responses {
+		if r.IsScript() && r.Body != "" {
+			captured[r.URL] = r
+		}
+	}
+
+	jsBlobs := content.scripts
+	inDOM := make(map[string]bool)
+	var missing []int
+	for i, blob := range jsBlobs {
+		if blob.Source != "external" {
+			continue
+		}
+		inDOM[blob.Path] = true
+		if r, ok := captured[blob.Path]; ok {
+			jsBlobs[i].Body = r.Body
+			jsBlobs[i].SourceMap = sourceMapHeader(r.Headers)
+		} else {
+			missing = append(missing, i)
+		}
+	}
+
+	// Fetch the remaining external scripts, dropping the ones that failed
+	urls := make([]string, len(missing))
+	for i, idx := range missing {
+		urls[i] = jsBlobs[idx].Path
+	}
+	for i, res := range h.fetcher.fetchAll(ctx, urls) {
+		if res.Err == nil {
+			jsBlobs[missing[i]].Body = res.Resp.Body
+			jsBlobs[missing[i]].SourceMap = sourceMapHeader(res.Resp.Header)
+		}
+	}
+
+	blobs := jsBlobs[:0]
+	for _, blob := range jsBlobs {
+		if blob.Source != "external" || blob.Body != "" {
+			blobs = append(blobs, blob)
+		}
+	}
+
+	// Add lazily loaded or injected scripts and every other textual response
+	for _, r := range content.responses {
+		if r.Body == "" || inDOM[r.URL] {
+			continue
+		}
+		switch {
+		case r.IsScript():
+			inDOM[r.URL] = true
+			blobs = append(blobs, JSBlob{
+				Source:    "external",
+				Path:      r.URL,
+				Body:      r.Body,
+				SourceMap: sourceMapHeader(r.Headers),
+			})
+		case r.Type == string(proto.NetworkResourceTypeDocument) && r.URL == content.url:
+			// The main document is scanned as HTML
+		default:
+			blobs = append(blobs, JSBlob{
+				Source: "network",
+				Path:   r.URL,
+				Body:   r.Body,
+			})
+		}
+	}
+
+	// Unpack original sources from any referenced source maps
+	blobs = append(blobs, h.fetcher.expandSourceMaps(ctx, blobs)...)
+
+	return &RenderResult{
+		URL:       content.url,
+		HTML:      content.html,
+		Headers:   documentHeaders(content.responses, content.url),
+		JSBlobs:   blobs,
+		Responses: content.responses,
+	}, nil
+}
+
+// load opens a tab, navigates to the target and extracts its content
+func (h *HeadlessRenderer) load(ctx context.Context, targetURL string) (*pageContent, error) {
+	// Reuse the shared browser, launching it on first use
+	browser, err := h.getBrowser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Isolate the page in its own incognito context
+	incognito, err := browser.Incognito()
+	if err != nil {
+		return nil, fmt.Errorf("creating browser context: %w", err)
+	}
+	defer incognito.Close()
+
+	tab, err := incognito.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return nil, fmt.Errorf("opening tab: %w", err)
+	}
+	defer tab.Close()
+
+	// All page operations honor the caller's context, the render timeout,
+	// and are aborted if the tab crashes
+	pageCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	page := tab.Context(pageCtx)
+
+	var crashed atomic.Bool
+	go page.EachEvent(func(e *proto.InspectorTargetCrashed) bool {
+		crashed.Store(true)
+		cancel()
+		return true
+	})()
+	fail := func(action string, err error) error {
+		switch {
+		case crashed.Load():
+			return &TargetCrashedError{URL: targetURL}
+		case errors.Is(pageCtx.Err(), context.DeadlineExceeded):
+			return &NavigationTimeoutError{URL: targetURL, Timeout: h.timeout}
+		case ctx.Err() != nil:
+			return ctx.Err()
+		}
+		return fmt.Errorf("%s: %w", action, err)
+	}
 
-	// Create a new page with timeout
-	page := browser.Timeout(h.timeout).MustPage()
-	defer page.MustClose()
+	// Record every response from here on
+	rec := recordNetwork(page)
 
 	// Navigate to the target URL
 	if err := page.Navigate(targetURL); err != nil {
-		return nil, fmt.Errorf("navigating to %s: %w", targetURL, err)
+		return nil, fail("navigating to "+targetURL, err)
 	}
 
 	// Wait for the page to load
 	if err := page.WaitLoad(); err != nil {
-		return nil, fmt.Errorf("waiting for page load: %w", err)
+		return nil, fail("waiting for page load", err)
 	}
 
 	// Give additional time for JavaScript execution
-	page.MustWaitIdle()
+	if err := page.WaitIdle(time.Minute); err != nil {
+		return nil, fail("waiting for page idle", err)
+	}
 
 	// Get the final URL (after redirects)
-	finalURL := page.MustInfo().URL
+	info, err := page.Info()
+	if err != nil {
+		return nil, fail("reading page info", err)
+	}
+	finalURL := info.URL
 
 	// Extract HTML
 	html, err := page.HTML()
 	if err != nil {
-		return nil, fmt.Errorf("extracting HTML: %w", err)
+		return nil, fail("extracting HTML", err)
 	}
 
 	// Extract JavaScript
-	jsBlobs, err := h.extractJavaScript(page, finalURL)
+	content, err := h.extractJavaScript(page, finalURL)
 	if err != nil {
-		return nil, fmt.Errorf("extracting JavaScript: %w", err)
+		return nil, fail("extracting JavaScript", err)
 	}
 
-	return &RenderResult{
-		URL:     finalURL,
-		HTML:    html,
-		Headers: nil, // TODO: Capture headers via request interception
-		JSBlobs: jsBlobs,
-	}, nil
+	content.url = finalURL
+	content.html = html
+	content.responses = rec.collect(page)
+	return content, nil
 }
 
-// extractJavaScript extracts all JavaScript from the page
-func (h *HeadlessRenderer) extractJavaScript(page *rod.Page, baseURL string) ([]JSBlob, error) {
-	var jsBlobs []JSBlob
+// extractJavaScript collects inline scripts and external script URLs from the page
+func (h *HeadlessRenderer) extractJavaScript(page *rod.Page, baseURL string) (*pageContent, error) {
+	content := &pageContent{}
 	inlineCounter := 0
 
 	// Get all script elements
@@ -99,7 +342,7 @@ func (h *HeadlessRenderer) extractJavaScript(page *rod.Page, baseURL string) ([]
 		// Check if it has a src attribute (external)
 		src, err := script.Attribute("src")
 		if err == nil && src != nil && *src != "" {
-			// External script - fetch content
+			// External script - fetched once the page is closed
 			scriptURL := *src
 
 			// Resolve relative URLs
@@ -110,20 +353,16 @@ func (h *HeadlessRenderer) extractJavaScript(page *rod.Page, baseURL string) ([]
 				}
 			}
 
-			content, err := h.fetchScript(scriptURL)
-			if err == nil {
-				jsBlobs = append(jsBlobs, JSBlob{
-					Source: "external",
-					Path:   scriptURL,
-					Body:   content,
-				})
-			}
+			content.scripts = append(content.scripts, JSBlob{
+				Source: "external",
+				Path:   scriptURL,
+			})
 		} else {
 			// Inline script
 			text, err := script.Text()
 			if err == nil && strings.TrimSpace(text) != "" {
 				inlineCounter++
-				jsBlobs = append(jsBlobs, JSBlob{
+				content.scripts = append(content.scripts, JSBlob{
 					Source: "inline",
 					Path:   fmt.Sprintf("%s#inline-%d", baseURL, inlineCounter),
 					Body:   text,
@@ -132,36 +371,5 @@ func (h *HeadlessRenderer) extractJavaScript(page *rod.Page, baseURL string) ([]
 		}
 	}
 
-	return jsBlobs, nil
-}
-
-// fetchScript fetches an external JavaScript file
-func (h *HeadlessRenderer) fetchScript(scriptURL string) (string, error) {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	req, err := http.NewRequest("GET", scriptURL, nil)
-	if err != nil {
-		return "", err
-	}
-
-	req.Header.Set("User-Agent", "webhog/0.1.0 (https://github.com/user/webhog)")
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	return string(body), nil
+	return content, nil
 }
@@ -0,0 +1,159 @@
+package renderer
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// maxNetworkBody caps the size of a captured response body
+const maxNetworkBody = 10 << 20
+
+// NetworkResponse is a response observed by the headless browser
+type NetworkResponse struct {
+	URL      string              // The response URL
+	Status   int                 // HTTP status code
+	Headers  map[string][]string // Response headers
+	MIMEType string              // MIME type reported by the browser
+	Type     string              // Resource type, e.g. "Document", "Script", "XHR", "Fetch"
+	Body     string              // Response body (empty for binary or oversized responses)
+}
+
+// IsScript reports whether the response carries JavaScript
+func (r NetworkResponse) IsScript() bool {
+	return r.Type == string(proto.NetworkResourceTypeScript) || strings.Contains(r.MIMEType, "javascript")
+}
+
+// networkRecorder collects every response a page receives
+type networkRecorder struct {
+	mu        sync.Mutex
+	order     []proto.NetworkRequestID
+	responses map[proto.NetworkRequestID]*NetworkResponse
+	finished  map[proto.NetworkRequestID]bool
+}
+
+// recordNetwork starts recording responses on the page. It must be called
+// before navigating.
+func recordNetwork(page *rod.Page) *networkRecorder {
+	rec := &networkRecorder{
+		responses: make(map[proto.NetworkRequestID]*NetworkResponse),
+		finished:  make(map[proto.NetworkRequestID]bool),
+	}
+
+	wait := page.EachEvent(func(e *proto.NetworkResponseReceived) {
+		if e.Response == nil {
+			return
+		}
+
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+
+		if _, ok := rec.responses[e.RequestID]; !ok {
+			rec.order = append(rec.order, e.RequestID)
+		}
+		rec.responses[e.RequestID] = &NetworkResponse{
+			URL:      e.Response.URL,
+			Status:   e.Response.Status,
+			Headers:  convertHeaders(e.Response.Headers),
+			MIMEType: e.Response.MIMEType,
+			Type:     string(e.Type),
+		}
+	}, func(e *proto.NetworkLoadingFinished) {
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+
+		if e.EncodedDataLength <= maxNetworkBody {
+			rec.finished[e.RequestID] = true
+		}
+	})
+	go wait()
+
+	return rec
+}
+
+// collect retrieves the bodies of all finished textual responses and returns
+// every response in the order it was received
+func (rec *networkRecorder) collect(page *rod.Page) []NetworkResponse {
+	rec.mu.Lock()
+	ids := append([]proto.NetworkRequestID(nil), rec.order...)
+	rec.mu.Unlock()
+
+	var responses []NetworkResponse
+	for _, id := range ids {
+		rec.mu.Lock()
+		resp := *rec.responses[id]
+		finished := rec.finished[id]
+		rec.mu.Unlock()
+
+		if finished && isTextual(resp.MIMEType) {
+			body, err := proto.NetworkGetResponseBody{RequestID: id}.Call(page)
+			if err == nil {
+				resp.Body = decodeBody(body)
+			}
+		}
+
+		responses = append(responses, resp)
+	}
+
+	return responses
+}
+
+// documentHeaders returns the headers of the main document response for finalURL
+func documentHeaders(responses []NetworkResponse, finalURL string) map[string][]string {
+	var first map[string][]string
+	for _, r := range responses {
+		if r.Type != string(proto.NetworkResourceTypeDocument) {
+			continue
+		}
+		if r.URL == finalURL {
+			return r.Headers
+		}
+		if first == nil {
+			first = r.Headers
+		}
+	}
+	return first
+}
+
+// convertHeaders converts CDP headers (multiple values joined by newlines)
+// into the net/http representation
+func convertHeaders(headers proto.NetworkHeaders) map[string][]string {
+	result := make(map[string][]string, len(headers))
+	for name, value := range headers {
+		key := http.CanonicalHeaderKey(name)
+		result[key] = append(result[key], strings.Split(value.Str(), "\n")...)
+	}
+	return result
+}
+
+// decodeBody returns the response body as text
+func decodeBody(body *proto.NetworkGetResponseBodyResult) string {
+	if !body.Base64Encoded {
+		return body.Body
+	}
+	decoded, err := base64.StdEncoding.DecodeString(body.Body)
+	if err != nil {
+		return ""
+	}
+	return string(decoded)
+}
+
+// isTextual reports whether a MIME type is worth scanning as text
+func isTextual(mimeType string) bool {
+	mimeType = strings.ToLower(mimeType)
+	switch {
+	case strings.HasPrefix(mimeType, "text/"):
+		return true
+	case strings.Contains(mimeType, "javascript"),
+		strings.Contains(mimeType, "json"),
+		strings.Contains(mimeType, "xml"),
+		strings.Contains(mimeType, "graphql"),
+		strings.Contains(mimeType, "x-www-form-urlencoded"):
+		return true
+	}
+	return false
+}
@@ -6,20 +6,25 @@ import (
 
 // JSBlob represents a JavaScript code blob found on a page
 type JSBlob struct {
-	Source string // "inline", "external", "network"
-	Path   string // URL or identifier like "URL#inline-N"
-	Body   string // The actual JavaScript content
+	Source    string // "inline", "external", "network", "chunk", "sourcemap"
+	Path      string // URL or identifier like "URL#inline-N" or "MAP_URL!src/file.ts"
+	Body      string // The actual JavaScript content
+	SourceMap string // Source map URL from a SourceMap response header, if any
 }
 
 // RenderResult contains the rendered page and all discovered JavaScript
 type RenderResult struct {
-	URL     string              // The final URL (after redirects)
-	HTML    string              // The page HTML
-	Headers map[string][]string // HTTP Response Headers
-	JSBlobs []JSBlob            // All JavaScript found
+	URL       string              // The final URL (after redirects)
+	HTML      string              // The page HTML
+	Headers   map[string][]string // HTTP Response Headers
+	JSBlobs   []JSBlob            // All JavaScript found
+	Responses []NetworkResponse   // Every response seen while rendering (headless only)
 }
 
-// Renderer defines the interface for fetching and rendering web pages
+// Renderer defines the interface for fetching and rendering web pages.
+// Render must be safe to call concurrently.
 type Renderer interface {
 	Render(ctx context.Context, targetURL string) (*RenderResult, error)
+	// Close releases any long-lived resources, such as a browser process
+	Close() error
 }
@@ -0,0 +1,174 @@
+package renderer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sourceMappingRe matches the trailing source map comment of a script
+var sourceMappingRe = regexp.MustCompile(`(?m)^[ \t]*//[#@][ \t]*sourceMappingURL=(\S+)[ \t]*$`)
+
+// sourceSchemeRe matches virtual scheme prefixes bundlers put on source paths,
+// e.g. "webpack://" or "vite://"
+var sourceSchemeRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://`)
+
+// sourceMap is the subset of the source map v3 format we need
+type sourceMap struct {
+	SourceRoot     string    `json:"sourceRoot"`
+	Sources        []string  `json:"sources"`
+	SourcesContent []*string `json:"sourcesContent"`
+}
+
+// sourceMapHeader returns the source map URL advertised in response headers
+func sourceMapHeader(header http.Header) string {
+	if v := header.Get("SourceMap"); v != "" {
+		return v
+	}
+	return header.Get("X-SourceMap")
+}
+
+// sourceMapURL returns the absolute URL of the blob's source map, or "" if it
+// doesn't reference one. A header takes precedence over the comment.
+func sourceMapURL(blob JSBlob) string {
+	ref := blob.SourceMap
+	if ref == "" {
+		matches := sourceMappingRe.FindAllStringSubmatch(blob.Body, -1)
+		if len(matches) == 0 {
+			return ""
+		}
+		ref = matches[len(matches)-1][1]
+	}
+
+	if strings.HasPrefix(ref, "data:") {
+		return ref
+	}
+
+	// Inline scripts resolve against the page URL
+	base := blob.Path
+	if i := strings.Index(base, "#"); i != -1 {
+		base = base[:i]
+	}
+
+	resolved, err := resolveURL(base, ref)
+	if err != nil {
+		return ""
+	}
+	return resolved
+}
+
+// expandSourceMaps fetches the source maps referenced by the blobs and
+// returns one virtual blob per original source file that has its content
+// embedded. Paths look like "https://host/bundle.js.map!src/config.ts".
+func (f *fetcher) expandSourceMaps(ctx context.Context, blobs []JSBlob) []JSBlob {
+	seen := make(map[string]bool)
+	var remote []string
+	var inline []string
+
+	for _, blob := range blobs {
+		mapURL := sourceMapURL(blob)
+		if mapURL == "" || seen[mapURL] {
+			continue
+		}
+		seen[mapURL] = true
+
+		if strings.HasPrefix(mapURL, "data:") {
+			inline = append(inline, mapURL)
+		} else {
+			remote = append(remote, mapURL)
+		}
+	}
+
+	var sources []JSBlob
+	for i, res := range f.fetchAll(ctx, remote) {
+		if res.Err == nil {
+			sources = append(sources, unpackSourceMap(remote[i], res.Resp.Body)...)
+		}
+	}
+	for i, dataURI := range inline {
+		if body, ok := DecodeDataURI(dataURI); ok {
+			// Data URIs make unwieldy paths, so name them after their position
+			sources = append(sources, unpackSourceMap("data:sourcemap-"+strconv.Itoa(i+1), body)...)
+		}
+	}
+
+	return sources
+}
+
+// unpackSourceMap turns the embedded sources of a source map into blobs
+func unpackSourceMap(mapURL, body string) []JSBlob {
+	var sm sourceMap
+	if err := json.Unmarshal([]byte(body), &sm); err != nil {
+		return nil
+	}
+
+	var blobs []JSBlob
+	for i, source := range sm.Sources {
+		if i >= len(sm.SourcesContent) || sm.SourcesContent[i] == nil {
+			continue
+		}
+
+		name := cleanSourcePath(sm.SourceRoot, source)
+		// Third-party code and bundler runtime only add noise
+		if strings.Contains(name, "node_modules/") || strings.HasPrefix(name, "webpack/") {
+			continue
+		}
+
+		blobs = append(blobs, JSBlob{
+			Source: "sourcemap",
+			Path:   mapURL + "!" + name,
+			Body:   *sm.SourcesContent[i],
+		})
+	}
+
+	return blobs
+}
+
+// cleanSourcePath strips bundler schemes and relative prefixes from a
+// source path, e.g. "webpack://app/./src/config.ts" -> "app/src/config.ts"
+func cleanSourcePath(root, source string) string {
+	name := source
+	if root != "" && !sourceSchemeRe.MatchString(source) && !strings.HasPrefix(source, "/") {
+		name = strings.TrimSuffix(root, "/") + "/" + source
+	}
+
+	name = sourceSchemeRe.ReplaceAllString(name, "")
+	name = strings.ReplaceAll(name, "/./", "/")
+	for strings.HasPrefix(name, "./") || strings.HasPrefix(name, "/") {
+		name = strings.TrimPrefix(strings.TrimPrefix(name, "./"), "/")
+	}
+	return name
+}
+
+// DecodeDataURI returns the payload of a base64 or percent-encoded data:
+// URI. A base64 payload may itself be percent-encoded and may lack its
+// padding, as both turn up in pages.
+func DecodeDataURI(uri string) (string, bool) {
+	rest, ok := strings.CutPrefix(uri, "data:")
+	if !ok {
+		return "", false
+	}
+	meta, data, ok := strings.Cut(rest, ",")
+	if !ok {
+		return "", false
+	}
+
+	data, err := url.PathUnescape(data)
+	if err != nil {
+		return "", false
+	}
+	if !strings.HasSuffix(meta, ";base64") {
+		return data, true
+	}
+
+	decoded, err := base64.RawStdEncoding.DecodeString(strings.TrimRight(data, "="))
+	if err != nil {
+		return "", false
+	}
+	return string(decoded), true
+}
@@ -3,26 +3,30 @@ package renderer
 import (
 	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/user/webhog/internal/pool"
 	"golang.org/x/net/html"
 )
 
 // StaticRenderer fetches pages using HTTP only (no JavaScript execution)
 type StaticRenderer struct {
-	client  *http.Client
+	fetcher *fetcher
 	timeout time.Duration
 }
 
-// NewStaticRenderer creates a new static renderer
-func NewStaticRenderer(timeout time.Duration) *StaticRenderer {
+// NewStaticRenderer creates a new static renderer. All requests it makes
+// are bounded by the given worker pool.
+func NewStaticRenderer(timeout time.Duration, p *pool.Pool) *StaticRenderer {
 	return &StaticRenderer{
-		client: &http.Client{
-			Timeout: timeout,
+		fetcher: &fetcher{
+			client: &http.Client{
+				Timeout: timeout,
+			},
+			pool: p,
 		},
 		timeout: timeout,
 	}
@@ -31,45 +35,31 @@ func NewStaticRenderer(timeout time.Duration) *StaticRenderer {
 // Render fetches a page and extracts HTML and JavaScript
 func (s *StaticRenderer) Render(ctx context.Context, targetURL string) (*RenderResult, error) {
 	// Fetch the HTML
-	req, err := http.NewRequestWithContext(ctx, "GET", targetURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("creating request: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "webhog/0.1.0 (https://github.com/user/webhog)")
-
-	resp, err := s.client.Do(req)
+	resp, err := s.fetcher.fetch(ctx, targetURL)
 	if err != nil {
 		return nil, fmt.Errorf("fetching page: %w", err)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("reading response: %w", err)
-	}
-
-	htmlContent := string(body)
-	finalURL := resp.Request.URL.String()
 
 	// Parse HTML and extract JavaScript
-	jsBlobs, err := s.extractJavaScript(ctx, htmlContent, finalURL)
+	jsBlobs, err := s.extractJavaScript(ctx, resp.Body, resp.URL)
 	if err != nil {
 		return nil, fmt.Errorf("extracting JavaScript: %w", err)
 	}
 
 	return &RenderResult{
-		URL:     finalURL,
-		HTML:    htmlContent,
+		URL:     resp.URL,
+		HTML:    resp.Body,
 		Headers: resp.Header,
 		JSBlobs: jsBlobs,
 	}, nil
 }
 
+// Close releases idle HTTP connections
+func (s *StaticRenderer) Close() error {
+	s.fetcher.client.CloseIdleConnections()
+	return nil
+}
+
 // extractJavaScript parses HTML and extracts all JavaScript (inline and external)
 func (s *StaticRenderer) extractJavaScript(ctx context.Context, htmlContent, baseURL string) ([]JSBlob, error) {
 	doc, err := html.Parse(strings.NewReader(htmlContent))
@@ -77,7 +67,11 @@ func (s *StaticRenderer) extractJavaScript(ctx context.Context, htmlContent, bas
 		return nil, fmt.Errorf("parsing HTML: %w", err)
 	}
 
+	// Collect scripts in document order; external ones are fetched afterwards
+	// so the downloads can run concurrently
 	var jsBlobs []JSBlob
+	var scriptURLs []string
+	var scriptIndex []int
 	inlineCounter := 0
 
 	var traverse func(*html.Node)
@@ -89,14 +83,12 @@ func (s *StaticRenderer) extractJavaScript(ctx context.Context, htmlContent, bas
 				// External script
 				scriptURL, err := resolveURL(baseURL, src)
 				if err == nil {
-					content, err := s.fetchScript(ctx, scriptURL)
-					if err == nil {
-						jsBlobs = append(jsBlobs, JSBlob{
-							Source: "external",
-							Path:   scriptURL,
-							Body:   content,
-						})
-					}
+					scriptIndex = append(scriptIndex, len(jsBlobs))
+					scriptURLs = append(scriptURLs, scriptURL)
+					jsBlobs = append(jsBlobs, JSBlob{
+						Source: "external",
+						Path:   scriptURL,
+					})
 				}
 			} else {
 				// Inline script
@@ -118,34 +110,31 @@ func (s *StaticRenderer) extractJavaScript(ctx context.Context, htmlContent, bas
 	}
 
 	traverse(doc)
-	return jsBlobs, nil
-}
 
-// fetchScript fetches an external JavaScript file
-func (s *StaticRenderer) fetchScript(ctx context.Context, scriptURL string) (string, error) {
-	req, err := http.NewRequestWithContext(ctx, "GET", scriptURL, nil)
-	if err != nil {
-		return "", err
+	// Fetch external scripts, dropping the ones that failed
+	fetched := make(map[int]bool)
+	for i, res := range s.fetcher.fetchAll(ctx, scriptURLs) {
+		if res.Err == nil {
+			jsBlobs[scriptIndex[i]].Body = res.Resp.Body
+			jsBlobs[scriptIndex[i]].SourceMap = sourceMapHeader(res.Resp.Header)
+			fetched[scriptIndex[i]] = true
+		}
 	}
 
-	req.Header.Set("User-Agent", "webhog/0.1.0 (https://github.com/user/webhog)")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return "", err
+	blobs := jsBlobs[:0]
+	for i, blob := range jsBlobs {
+		if blob.Source != "external" || fetched[i] {
+			blobs = append(blobs, blob)
+		}
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
-	}
+	// Fetch lazily loaded webpack/Vite chunks the browser would request later
+	blobs = append(blobs, s.fetcher.enumerateChunks(ctx, baseURL, blobs)...)
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
+	// Unpack original sources from any referenced source maps
+	blobs = append(blobs, s.fetcher.expandSourceMaps(ctx, blobs)...)
 
-	return string(body), nil
+	return blobs, nil
 }
 
 // getAttr returns the value of an attribute from an HTML node
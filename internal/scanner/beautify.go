@@ -0,0 +1,359 @@
+package scanner
+
+import (
+	"sort"
+	"strings"
+)
+
+// minifiedLineLength is the line length past which a blob is taken to be
+// minified and worth beautifying
+const minifiedLineLength = 1000
+
+// minified reports whether JavaScript looks minified, i.e. has a line far
+// longer than hand-written code would
+func minified(text string) bool {
+	for len(text) > minifiedLineLength {
+		i := strings.IndexByte(text, '\n')
+		if i == -1 || i > minifiedLineLength {
+			return true
+		}
+		text = text[i+1:]
+	}
+	return false
+}
+
+// span is a run of the original text copied to the beautified text
+type span struct {
+	out, in, n int // Offset in the beautified text, in the original, and length
+}
+
+// offsetMap maps offsets in beautified text back to the original
+type offsetMap struct {
+	spans []span
+}
+
+// original returns the offset in the original text of a beautified
+// offset. Offsets in inserted whitespace map to the end of the text
+// copied before it.
+func (m *offsetMap) original(offset int) int {
+	i := sort.Search(len(m.spans), func(i int) bool { return m.spans[i].out > offset }) - 1
+	if i < 0 {
+		return 0
+	}
+	sp := m.spans[i]
+	if offset < sp.out+sp.n {
+		return sp.in + offset - sp.out
+	}
+	return sp.in + sp.n
+}
+
+// originalEnd returns the original offset of the end of a range, which is
+// just past the last byte of the range
+func (m *offsetMap) originalEnd(offset int) int {
+	if offset == 0 {
+		return 0
+	}
+	return m.original(offset-1) + 1
+}
+
+// tokenKind is the lexical class of a JavaScript token
+type tokenKind int
+
+const (
+	tokenPunct  tokenKind = iota
+	tokenWord             // Identifier, keyword or number
+	tokenString           // String, template or regex literal
+	tokenLineComment
+	tokenBlockComment
+)
+
+// regexKeywords are the keywords after which a slash starts a regex
+var regexKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true,
+	"new": true, "delete": true, "void": true, "throw": true, "case": true,
+	"do": true, "else": true, "yield": true, "await": true,
+}
+
+// beautifier writes JavaScript out one statement per line, recording
+// where each piece of the original ends up
+type beautifier struct {
+	src   string
+	out   strings.Builder
+	spans []span
+
+	indent    int
+	lineStart bool // Nothing but indentation is on the current line
+	space     bool // A space is due before the next token
+}
+
+// beautify pretty-prints JavaScript: statements and object members go on
+// their own lines, indented by block. Tokens are copied unchanged, so a
+// regex matching the original matches the result. Only whitespace is
+// inserted or dropped, and the returned map takes offsets in the result
+// back to the original.
+func beautify(src string) (string, *offsetMap) {
+	b := &beautifier{src: src, lineStart: true}
+	b.out.Grow(len(src) + len(src)/4)
+
+	var open []byte        // Unclosed brackets
+	var prevKind tokenKind // The last token other than a comment
+	prevText := ""
+	closedBlock := false // The last token closed a block
+
+	for i := 0; i < len(src); {
+		if isSpace(src[i]) {
+			newline := false
+			for ; i < len(src) && isSpace(src[i]); i++ {
+				newline = newline || src[i] == '\n'
+			}
+			if newline {
+				b.newline()
+			} else {
+				b.space = true
+			}
+			continue
+		}
+
+		end, kind := scanToken(src, i, regexAllowed(prevKind, prevText))
+		text := src[i:end]
+
+		// A closed block ends the line unless the statement goes on
+		if closedBlock {
+			switch {
+			case kind == tokenWord && (text == "else" || text == "catch" || text == "finally" || text == "while"):
+				b.space = true
+			case kind == tokenPunct && strings.Contains(")],;.(?:", text):
+			default:
+				b.newline()
+			}
+			closedBlock = false
+		}
+
+		switch {
+		case kind == tokenLineComment:
+			b.copy(i, end)
+			b.newline()
+		case kind == tokenBlockComment:
+			b.copy(i, end)
+		case text == "{":
+			b.copy(i, end)
+			open = append(open, '{')
+			b.indent++
+			b.newline()
+		case text == "}":
+			if n := len(open); n > 0 && open[n-1] == '{' {
+				open = open[:n-1]
+			}
+			if b.indent > 0 {
+				b.indent--
+			}
+			b.newline()
+			b.copy(i, end)
+			closedBlock = true
+		case text == "(" || text == "[":
+			b.copy(i, end)
+			open = append(open, text[0])
+		case text == ")" || text == "]":
+			if n := len(open); n > 0 && open[n-1] != '{' {
+				open = open[:n-1]
+			}
+			b.copy(i, end)
+		case text == ";":
+			b.copy(i, end)
+			if n := len(open); n == 0 || open[n-1] != '(' {
+				b.newline()
+			}
+		case text == ",":
+			b.copy(i, end)
+			if n := len(open); n == 0 || open[n-1] == '{' {
+				b.newline()
+			}
+		default:
+			b.copy(i, end)
+		}
+
+		if kind != tokenLineComment && kind != tokenBlockComment {
+			prevKind, prevText = kind, text
+		}
+		i = end
+	}
+
+	return b.out.String(), &offsetMap{spans: b.spans}
+}
+
+// copy writes src[start:end] to the output
+func (b *beautifier) copy(start, end int) {
+	if b.lineStart {
+		b.out.WriteString(strings.Repeat("  ", b.indent))
+	} else if b.space {
+		b.out.WriteByte(' ')
+	}
+	b.lineStart, b.space = false, false
+
+	// Extend the last span when this piece follows on in both texts
+	out := b.out.Len()
+	if n := len(b.spans); n > 0 {
+		last := &b.spans[n-1]
+		if last.out+last.n == out && last.in+last.n == start {
+			last.n += end - start
+			b.out.WriteString(b.src[start:end])
+			return
+		}
+	}
+	b.spans = append(b.spans, span{out: out, in: start, n: end - start})
+	b.out.WriteString(b.src[start:end])
+}
+
+// newline ends the current line, unless it is empty
+func (b *beautifier) newline() {
+	if !b.lineStart {
+		b.out.WriteByte('\n')
+	}
+	b.lineStart, b.space = true, false
+}
+
+// regexAllowed reports whether a slash after the given token starts a
+// regex rather than a division
+func regexAllowed(kind tokenKind, text string) bool {
+	switch kind {
+	case tokenWord:
+		return regexKeywords[text]
+	case tokenString:
+		return false
+	}
+	return text != ")" && text != "]" && text != "}"
+}
+
+// scanToken returns the end and kind of the token starting at src[i],
+// which is not whitespace
+func scanToken(src string, i int, regex bool) (int, tokenKind) {
+	c := src[i]
+	var next byte
+	if i+1 < len(src) {
+		next = src[i+1]
+	}
+
+	switch {
+	case c == '/' && next == '/':
+		if end := strings.IndexByte(src[i:], '\n'); end != -1 {
+			return i + end, tokenLineComment
+		}
+		return len(src), tokenLineComment
+	case c == '/' && next == '*':
+		if end := strings.Index(src[i+2:], "*/"); end != -1 {
+			return i + 2 + end + 2, tokenBlockComment
+		}
+		return len(src), tokenBlockComment
+	case c == '"' || c == '\'':
+		return scanString(src, i), tokenString
+	case c == '`':
+		return scanTemplate(src, i), tokenString
+	case c == '/' && regex:
+		if end := scanRegex(src, i); end != -1 {
+			return end, tokenString
+		}
+	case isWordByte(c):
+		end := i + 1
+		for end < len(src) && isWordByte(src[end]) {
+			end++
+		}
+		return end, tokenWord
+	}
+	return i + 1, tokenPunct
+}
+
+// scanString returns the end of the quoted string starting at src[i]. An
+// unterminated string ends at the end of its line.
+func scanString(src string, i int) int {
+	quote := src[i]
+	for i++; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		case '\n':
+			return i
+		}
+	}
+	return len(src)
+}
+
+// scanTemplate returns the end of the template literal starting at
+// src[i], including any substitutions in it
+func scanTemplate(src string, i int) int {
+	for i++; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case '`':
+			return i + 1
+		case '$':
+			if i+1 < len(src) && src[i+1] == '{' {
+				i = scanSubstitution(src, i+2) - 1
+			}
+		}
+	}
+	return len(src)
+}
+
+// scanSubstitution returns the end of a template substitution whose
+// expression starts at src[i], just past its closing brace
+func scanSubstitution(src string, i int) int {
+	depth := 0
+	kind, text := tokenPunct, ""
+	for i < len(src) {
+		if isSpace(src[i]) {
+			i++
+			continue
+		}
+		end, k := scanToken(src, i, regexAllowed(kind, text))
+		switch src[i:end] {
+		case "{":
+			depth++
+		case "}":
+			if depth == 0 {
+				return end
+			}
+			depth--
+		}
+		kind, text, i = k, src[i:end], end
+	}
+	return len(src)
+}
+
+// scanRegex returns the end of the regex literal starting at src[i],
+// flags included, or -1 if the line ends first
+func scanRegex(src string, i int) int {
+	inClass := false
+	for i++; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case '[':
+			inClass = true
+		case ']':
+			inClass = false
+		case '/':
+			if !inClass {
+				for i++; i < len(src) && isWordByte(src[i]); i++ {
+				}
+				return i
+			}
+		case '\n':
+			return -1
+		}
+	}
+	return -1
+}
+
+// isSpace reports whether c is JavaScript whitespace
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+// isWordByte reports whether c can be part of an identifier or number;
+// bytes of non-ASCII characters count, as they are mostly identifiers
+func isWordByte(c byte) bool {
+	return c >= 0x80 || c == '_' || c == '$' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
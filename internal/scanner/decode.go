@@ -0,0 +1,288 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/user/webhog/internal/renderer"
+)
+
+// DefaultDecodeDepth is how many nested encodings are decoded by default
+const DefaultDecodeDepth = 2
+
+// minDecodedLength is the shortest decoded text worth scanning
+const minDecodedLength = 8
+
+// encoding finds and decodes one kind of encoded content
+type encoding struct {
+	name   string
+	find   func(text string) [][2]int // Bounds of the candidate encoded texts
+	decode func(string) (string, bool)
+}
+
+// encodings are tried in order; content claimed by one is not decoded
+// again by a later one. Bare base64 comes last as hex digits, escapes and
+// parts of URL-encoded text are also valid base64.
+//
+// Only data: URIs and atob calls start with a literal, so the others are
+// found by scanning for runs of their characters; a regex starting with a
+// repetition is far slower on large bundles.
+var encodings = []encoding{
+	{name: "data-uri", find: findGroup(dataURI), decode: renderer.DecodeDataURI},
+	{name: "base64", find: findGroup(atobCall), decode: decodeBase64},
+	{name: "hex", find: findHex, decode: decodeHex},
+	{name: "escape", find: findEscapes, decode: decodeEscapes},
+	{name: "url", find: findURLEncoded, decode: decodeURL},
+	{name: "base64", find: findBase64, decode: decodeBase64},
+}
+
+var (
+	// dataURI matches a data: URI
+	dataURI = regexp.MustCompile(`data:[a-zA-Z0-9.+/-]*(?:;[a-zA-Z0-9.+=-]+)*,[A-Za-z0-9+/=%._~!$&'()*,;:@-]+`)
+
+	// atobCall matches a call to atob with a string literal; the group
+	// holds the base64
+	atobCall = regexp.MustCompile("atob\\(\\s*[\"'`]([A-Za-z0-9+/=_-]+)[\"'`]\\s*\\)")
+
+	// percentEscape matches a percent-encoded byte
+	percentEscape = regexp.MustCompile(`%[0-9A-Fa-f]{2}`)
+
+	hexChars    = newByteClass("0-9a-fA-F")
+	wordChars   = newByteClass("A-Za-z0-9_$")
+	escapeChars = newByteClass(`A-Za-z0-9_$+/=.:{}\-`)
+	urlChars    = newByteClass("A-Za-z0-9_.~!*:@&=+$,/?#%-")
+	base64Chars = newByteClass("A-Za-z0-9+/_-")
+)
+
+// byteClass is a set of bytes, like a regex character class
+type byteClass [256]bool
+
+// newByteClass builds a class from characters and a-z style ranges; a
+// literal '-' goes last
+func newByteClass(chars string) *byteClass {
+	var c byteClass
+	for i := 0; i < len(chars); i++ {
+		if i+2 < len(chars) && chars[i+1] == '-' {
+			for b := int(chars[i]); b <= int(chars[i+2]); b++ {
+				c[b] = true
+			}
+			i += 2
+			continue
+		}
+		c[chars[i]] = true
+	}
+	return &c
+}
+
+// runs returns the bounds of the maximal runs of at least min bytes of
+// text in the class
+func (c *byteClass) runs(text string, min int) [][2]int {
+	var runs [][2]int
+	for i := 0; i < len(text); {
+		if !c[text[i]] {
+			i++
+			continue
+		}
+		j := i
+		for j < len(text) && c[text[j]] {
+			j++
+		}
+		if j-i >= min {
+			runs = append(runs, [2]int{i, j})
+		}
+		i = j
+	}
+	return runs
+}
+
+// findGroup finds the matches of re, or of its first group when it has
+// one
+func findGroup(re *regexp.Regexp) func(string) [][2]int {
+	return func(text string) [][2]int {
+		var found [][2]int
+		for _, loc := range re.FindAllStringSubmatchIndex(text, -1) {
+			if len(loc) > 2 && loc[2] >= 0 {
+				found = append(found, [2]int{loc[2], loc[3]})
+			} else {
+				found = append(found, [2]int{loc[0], loc[1]})
+			}
+		}
+		return found
+	}
+}
+
+// findHex finds hex strings of at least 10 bytes that are whole words
+func findHex(text string) [][2]int {
+	var found [][2]int
+	for _, run := range hexChars.runs(text, 20) {
+		start, end := run[0], run[1]
+		if (end-start)%2 != 0 || (start > 0 && wordChars[text[start-1]]) || (end < len(text) && wordChars[text[end]]) {
+			continue
+		}
+		found = append(found, run)
+	}
+	return found
+}
+
+// findEscapes finds strings written with \uNNNN, \u{N...} or \xNN escapes
+func findEscapes(text string) [][2]int {
+	var found [][2]int
+	for _, run := range escapeChars.runs(text, 8) {
+		s := text[run[0]:run[1]]
+		if strings.Contains(s, `\u`) || strings.Contains(s, `\x`) {
+			found = append(found, run)
+		}
+	}
+	return found
+}
+
+// findURLEncoded finds text with percent-encoded characters
+func findURLEncoded(text string) [][2]int {
+	var found [][2]int
+	for _, run := range urlChars.runs(text, 6) {
+		if percentEscape.MatchString(text[run[0]:run[1]]) {
+			found = append(found, run)
+		}
+	}
+	return found
+}
+
+// findBase64 finds runs of at least 20 base64 characters with their
+// padding
+func findBase64(text string) [][2]int {
+	runs := base64Chars.runs(text, 20)
+	for i, run := range runs {
+		for end := run[1]; end < len(text) && end < run[1]+2 && text[end] == '='; end++ {
+			runs[i][1] = end + 1
+		}
+	}
+	return runs
+}
+
+// encodedSegment is a piece of text that decodes to readable content
+type encodedSegment struct {
+	start, end int // Bounds of the encoded text
+	encoding   string
+	decoded    string
+}
+
+// findEncoded returns the encoded segments of text that decode to
+// readable text, in order of the encodings tried
+func findEncoded(text string) []encodedSegment {
+	var segments []encodedSegment
+	for _, enc := range encodings {
+		for _, bounds := range enc.find(text) {
+			start, end := bounds[0], bounds[1]
+			if overlaps(segments, start, end) {
+				continue
+			}
+
+			decoded, ok := enc.decode(text[start:end])
+			if !ok || len(decoded) < minDecodedLength || decoded == text[start:end] || !readable(decoded) {
+				continue
+			}
+			segments = append(segments, encodedSegment{start: start, end: end, encoding: enc.name, decoded: decoded})
+		}
+	}
+	return segments
+}
+
+// overlaps reports whether start:end overlaps any of the segments
+func overlaps(segments []encodedSegment, start, end int) bool {
+	for _, seg := range segments {
+		if start < seg.end && seg.start < end {
+			return true
+		}
+	}
+	return false
+}
+
+// readable reports whether decoded content is text rather than binary
+// data or the garbage of decoding something that was not encoded
+func readable(text string) bool {
+	if !utf8.ValidString(text) {
+		return false
+	}
+	for _, r := range text {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeURL decodes percent-encoded text
+func decodeURL(s string) (string, bool) {
+	decoded, err := url.PathUnescape(s)
+	return decoded, err == nil
+}
+
+// decodeBase64 decodes standard or URL-safe base64, padded or not
+func decodeBase64(s string) (string, bool) {
+	s = strings.TrimRight(s, "=")
+	enc := base64.RawStdEncoding
+	if strings.ContainsAny(s, "-_") {
+		if strings.ContainsAny(s, "+/") {
+			return "", false
+		}
+		enc = base64.RawURLEncoding
+	}
+	decoded, err := enc.DecodeString(s)
+	if err != nil {
+		return "", false
+	}
+	return string(decoded), true
+}
+
+// decodeHex decodes a hex string
+func decodeHex(s string) (string, bool) {
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return "", false
+	}
+	return string(decoded), true
+}
+
+// decodeEscapes decodes the \uNNNN, \u{N...} and \xNN escapes of a
+// JavaScript string
+func decodeEscapes(s string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '\\' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		var digits string
+		switch {
+		case s[i+1] == 'u' && i+2 < len(s) && s[i+2] == '{':
+			end := strings.IndexByte(s[i:], '}')
+			if end == -1 {
+				return "", false
+			}
+			digits, i = s[i+3:i+end], i+end+1
+		case s[i+1] == 'u' && i+6 <= len(s):
+			digits, i = s[i+2:i+6], i+6
+		case s[i+1] == 'x' && i+4 <= len(s):
+			digits, i = s[i+2:i+4], i+4
+		default:
+			b.WriteByte(s[i])
+			i++
+			continue
+		}
+
+		r, err := strconv.ParseUint(digits, 16, 32)
+		if err != nil || r > unicode.MaxRune {
+			return "", false
+		}
+		b.WriteRune(rune(r))
+	}
+	return b.String(), true
+}
@@ -2,274 +2,367 @@ package scanner
 
 import "regexp"
 
-// GetDetectors returns all built-in detectors
 // GetDetectors returns all built-in detectors
 func GetDetectors() []Detector {
 	return []Detector{
 		// AWS Secrets
 		{
-			Name: "AWS Access Key ID",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`((?:A3T[A-Z0-9]|AKIA|AGPA|AIDA|AROA|AIPA|ANPA|ANVA|ASIA)[A-Z0-9]{16})`),
+			Name:     "AWS Access Key ID",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`((?:A3T[A-Z0-9]|AKIA|AGPA|AIDA|AROA|AIPA|ANPA|ANVA|ASIA)[A-Z0-9]{16})`),
+			Keywords: []string{"akia", "asia", "agpa", "aida", "aroa", "aipa", "anpa", "anva", "a3t"},
+			Severity: SeverityHigh,
 		},
 		{
-			Name: "AWS Secret Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(?i)aws_?secret_?access_?key[\s:=]+["\']?([A-Za-z0-9/+=]{40})["\']?`),
+			Name:     "AWS Secret Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(?i)aws_?secret_?access_?key[\s:=]+["\']?([A-Za-z0-9/+=]{40})["\']?`),
+			Keywords: []string{"awssecret", "aws_secret"},
+			Severity: SeverityCritical,
 		},
 		{
-			Name: "Amazon MWS Auth Token",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(amzn\.mws\.[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})`),
+			Name:     "Amazon MWS Auth Token",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(amzn\.mws\.[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})`),
+			Keywords: []string{"amzn.mws."},
+			Severity: SeverityHigh,
 		},
 		{
-			Name: "AWS AppSync GraphQL Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(da2-[a-z0-9]{26})`),
+			Name:     "AWS AppSync GraphQL Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(da2-[a-z0-9]{26})`),
+			Keywords: []string{"da2-"},
+			Severity: SeverityMedium,
 		},
 
 		// Google
 		{
-			Name: "Google API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(AIza[0-9A-Za-z\\-_]{35})`),
+			Name:     "Google API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(AIza[0-9A-Za-z\\-_]{35})`),
+			Keywords: []string{"aiza"},
+			Severity: SeverityMedium,
 		},
 		{
-			Name: "Google OAuth",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(?i)client_?secret[\s:=]+["\']?([0-9a-zA-Z\-_]{24})["\']?`),
+			Name:     "Google OAuth",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(?i)client_?secret[\s:=]+["\']?([0-9a-zA-Z\-_]{24})["\']?`),
+			Keywords: []string{"clientsecret", "client_secret"},
+			Severity: SeverityHigh,
 		},
 		{
-			Name: "Google Service Account",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`("type": "service_account")`),
+			Name:      "Google Service Account",
+			Type:      DetectorSecret,
+			Re:        regexp.MustCompile(`((?:\{[^{}]*?)?\\?["']?type\\?["']?\s*:\s*\\?["']service_account\\?["'](?:[^{}]*\})?)`),
+			Keywords:  []string{"service_account"},
+			Severity:  SeverityHigh,
+			MultiLine: true,
+			Parse:     parseServiceAccount,
 		},
 
 		// Facebook
 		{
-			Name: "Facebook Access Token",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(EAACEdEose0cBA[0-9A-Za-z]+)`),
+			Name:     "Facebook Access Token",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(EAACEdEose0cBA[0-9A-Za-z]+)`),
+			Keywords: []string{"eaacedeose0cba"},
+			Severity: SeverityHigh,
 		},
 		{
-			Name: "Facebook OAuth",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(?i)[fF][aA][cC][eE][bB][oO][oO][kK].{0,20}['|"][0-9a-f]{32}['|"]`),
+			Name:     "Facebook OAuth",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(?i)[fF][aA][cC][eE][bB][oO][oO][kK].{0,20}['|"]([0-9a-f]{32})['|"]`),
+			Keywords: []string{"facebook"},
+			Severity: SeverityMedium,
 		},
 
 		// Stripe
 		{
-			Name: "Stripe API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(sk_live_[0-9a-zA-Z]{24,})`),
+			Name:     "Stripe API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(sk_live_[0-9a-zA-Z]{24,})`),
+			Keywords: []string{"sk_live_"},
+			Severity: SeverityCritical,
 		},
 		{
-			Name: "Stripe Publishable Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(pk_live_[0-9a-zA-Z]{24,})`),
+			Name:     "Stripe Publishable Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(pk_live_[0-9a-zA-Z]{24,})`),
+			Keywords: []string{"pk_live_"},
+			Severity: SeverityLow,
 		},
 		{
-			Name: "Stripe Restricted API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(rk_live_[0-9a-zA-Z]{24,})`),
+			Name:     "Stripe Restricted API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(rk_live_[0-9a-zA-Z]{24,})`),
+			Keywords: []string{"rk_live_"},
+			Severity: SeverityHigh,
 		},
 
 		// GitHub
 		{
-			Name: "GitHub Personal Access Token",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(ghp_[0-9a-zA-Z]{36})`),
+			Name:     "GitHub Personal Access Token",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(ghp_[0-9a-zA-Z]{36})`),
+			Keywords: []string{"ghp_"},
+			Severity: SeverityCritical,
 		},
 		{
-			Name: "GitHub OAuth Token",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(gho_[0-9a-zA-Z]{36})`),
+			Name:     "GitHub OAuth Token",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(gho_[0-9a-zA-Z]{36})`),
+			Keywords: []string{"gho_"},
+			Severity: SeverityHigh,
 		},
 		{
-			Name: "GitHub Legacy Token",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(?i)[gG][iI][tT][hH][uU][bB].{0,20}['|"][0-9a-zA-Z]{35,40}['|"]`),
+			Name:     "GitHub Legacy Token",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(?i)[gG][iI][tT][hH][uU][bB].{0,20}['|"]([0-9a-zA-Z]{35,40})['|"]`),
+			Keywords: []string{"github"},
+			Severity: SeverityMedium,
 		},
 		{
-			Name: "GitHub Auth Creds",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(https://[a-zA-Z0-9]{40}@github\.com)`),
+			Name:     "GitHub Auth Creds",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(https://[a-zA-Z0-9]{40}@github\.com)`),
+			Keywords: []string{"@github.com"},
+			Severity: SeverityCritical,
 		},
 
 		// Heroku
 		{
-			Name: "Heroku API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(?i)[hH][eE][rR][oO][kK][uU].{0,20}[0-9A-F]{8}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{12}`),
+			Name:     "Heroku API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(?i)[hH][eE][rR][oO][kK][uU].{0,20}([0-9A-F]{8}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{4}-[0-9A-F]{12})`),
+			Keywords: []string{"heroku"},
+			Severity: SeverityHigh,
 		},
 
 		// MailChimp
 		{
-			Name: "MailChimp API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`([0-9a-f]{32}-us[0-9]{1,2})`),
+			Name:     "MailChimp API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`([0-9a-f]{32}-us[0-9]{1,2})`),
+			Keywords: []string{"-us"},
+			Severity: SeverityMedium,
 		},
 
 		// PayPal
 		{
-			Name: "PayPal Braintree Access Token",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(access_token\$production\$[0-9a-z]{16}\$[0-9a-f]{32})`),
+			Name:     "PayPal Braintree Access Token",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(access_token\$production\$[0-9a-z]{16}\$[0-9a-f]{32})`),
+			Keywords: []string{"access_token$production$"},
+			Severity: SeverityCritical,
 		},
 
 		// Picatic
 		{
-			Name: "Picatic API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(sk_live_[0-9a-z]{32})`),
+			Name:     "Picatic API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(sk_live_[0-9a-z]{32})`),
+			Keywords: []string{"sk_live_"},
+			Severity: SeverityMedium,
 		},
 
 		// Square
 		{
-			Name: "Square Access Token",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(sq0atp-[0-9A-Za-z\\-_]{22})`),
+			Name:     "Square Access Token",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(sq0atp-[0-9A-Za-z\\-_]{22})`),
+			Keywords: []string{"sq0atp-"},
+			Severity: SeverityHigh,
 		},
 		{
-			Name: "Square OAuth Secret",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(sq0csp-[0-9A-Za-z\\-_]{43})`),
+			Name:     "Square OAuth Secret",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(sq0csp-[0-9A-Za-z\\-_]{43})`),
+			Keywords: []string{"sq0csp-"},
+			Severity: SeverityHigh,
 		},
 
 		// Telegram
 		{
-			Name: "Telegram Bot API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`([0-9]+:AA[0-9A-Za-z\\-_]{33})`),
+			Name:     "Telegram Bot API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`([0-9]+:AA[0-9A-Za-z\\-_]{33})`),
+			Keywords: []string{":aa"},
+			Severity: SeverityMedium,
 		},
 
 		// JWT
 		{
-			Name: "JWT Token",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,})`),
+			Name:     "JWT Token",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,})`),
+			Keywords: []string{"eyj"},
+			Severity: SeverityMedium,
 		},
 
 		// Generic API Keys
 		{
-			Name: "Generic API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(?i)api[_-]?key[\s:=]+["\']([A-Za-z0-9\-_]{20,})["\']`),
+			Name:     "Generic API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(?i)api[_-]?key[\s:=]+["\']([A-Za-z0-9\-_]{20,})["\']`),
+			Keywords: []string{"apikey", "api_key", "api-key"},
+			Severity: SeverityMedium,
 		},
 		{
-			Name: "Generic Token",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(?i)(?:token|auth)[\s:=]+["\']([A-Za-z0-9\-_\.]{20,})["\']`),
+			Name:     "Generic Token",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(?i)(?:token|auth)[\s:=]+["\']([A-Za-z0-9\-_\.]{20,})["\']`),
+			Keywords: []string{"token", "auth"},
+			Severity: SeverityMedium,
 		},
 		{
-			Name: "Generic Secret",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(?i)secret[\s:=]+["\']([A-Za-z0-9\-_]{20,})["\']`),
+			Name:     "Generic Secret",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(?i)secret[\s:=]+["\']([A-Za-z0-9\-_]{20,})["\']`),
+			Keywords: []string{"secret"},
+			Severity: SeverityMedium,
 		},
 		{
-			Name: "Generic Password",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(?i)password[\s:=]+["\']([^"'\s]{8,})["\']`),
+			Name:     "Generic Password",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(?i)password[\s:=]+["\']([^"'\s]{8,})["\']`),
+			Keywords: []string{"password"},
+			Severity: SeverityHigh,
 		},
 		{
-			Name: "Password in URL",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`([a-zA-Z]{3,10}://[^/\s:@]{3,20}:[^/\s:@]{3,20}@.{1,100}["'\s])`),
+			Name:     "Password in URL",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`([a-zA-Z]{3,10}://[^/\s:@]{3,20}:[^/\s:@]{3,20}@.{1,100}["'\s])`),
+			Keywords: []string{"://"},
+			Severity: SeverityHigh,
 		},
 
 		// Database URLs
 		{
-			Name: "PostgreSQL Connection",
-			Type: DetectorConfig,
-			Re:   regexp.MustCompile(`(postgres(?:ql)?://[^\s'"]+)`),
+			Name:     "PostgreSQL Connection",
+			Type:     DetectorConfig,
+			Re:       regexp.MustCompile(`(postgres(?:ql)?://[^\s'"]+)`),
+			Keywords: []string{"postgres"},
+			Severity: SeverityHigh,
 		},
 		{
-			Name: "MySQL Connection",
-			Type: DetectorConfig,
-			Re:   regexp.MustCompile(`(mysql://[^\s'"]+)`),
+			Name:     "MySQL Connection",
+			Type:     DetectorConfig,
+			Re:       regexp.MustCompile(`(mysql://[^\s'"]+)`),
+			Keywords: []string{"mysql://"},
+			Severity: SeverityHigh,
 		},
 		{
-			Name: "MongoDB Connection",
-			Type: DetectorConfig,
-			Re:   regexp.MustCompile(`(mongodb(?:\+srv)?://[^\s'"]+)`),
+			Name:     "MongoDB Connection",
+			Type:     DetectorConfig,
+			Re:       regexp.MustCompile(`(mongodb(?:\+srv)?://[^\s'"]+)`),
+			Keywords: []string{"mongodb"},
+			Severity: SeverityHigh,
 		},
 		{
-			Name: "Redis Connection",
-			Type: DetectorConfig,
-			Re:   regexp.MustCompile(`(redis://[^\s'"]+)`),
+			Name:     "Redis Connection",
+			Type:     DetectorConfig,
+			Re:       regexp.MustCompile(`(redis://[^\s'"]+)`),
+			Keywords: []string{"redis://"},
+			Severity: SeverityMedium,
 		},
 
 		// Endpoints
 		{
-			Name: "Relative URL",
-			Type: DetectorEndpoint,
-			Re:   regexp.MustCompile(`["'](/[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+(?:/[a-zA-Z0-9_.-]+)*)["']`),
+			Name:     "Relative URL",
+			Type:     DetectorEndpoint,
+			Re:       regexp.MustCompile(`["'](/[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+(?:/[a-zA-Z0-9_.-]+)*)["']`),
+			Severity: SeverityInfo,
 		},
 		{
-			Name: "HTTP URL",
-			Type: DetectorEndpoint,
-			Re:   regexp.MustCompile(`(https?://[^\s"'<>]+)`),
+			Name:     "HTTP URL",
+			Type:     DetectorEndpoint,
+			Re:       regexp.MustCompile(`(https?://[^\s"'<>]+)`),
+			Keywords: []string{"http"},
+			Severity: SeverityInfo,
 		},
 		{
-			Name: "WebSocket URL",
-			Type: DetectorEndpoint,
-			Re:   regexp.MustCompile(`(wss?://[^\s"'<>]+)`),
+			Name:     "WebSocket URL",
+			Type:     DetectorEndpoint,
+			Re:       regexp.MustCompile(`(wss?://[^\s"'<>]+)`),
+			Keywords: []string{"ws://", "wss://"},
+			Severity: SeverityInfo,
 		},
 		{
-			Name: "API Endpoint",
-			Type: DetectorEndpoint,
-			Re:   regexp.MustCompile(`["'](/api/[^\s"']+)["']`),
+			Name:     "API Endpoint",
+			Type:     DetectorEndpoint,
+			Re:       regexp.MustCompile(`["'](/api/[^\s"']+)["']`),
+			Keywords: []string{"/api/"},
+			Severity: SeverityInfo,
 		},
 		{
-			Name: "GraphQL Endpoint",
-			Type: DetectorEndpoint,
-			Re:   regexp.MustCompile(`["']([^"']*graphql[^"']*)["']`),
+			Name:     "GraphQL Endpoint",
+			Type:     DetectorEndpoint,
+			Re:       regexp.MustCompile(`["']([^"']*graphql[^"']*)["']`),
+			Keywords: []string{"graphql"},
+			Severity: SeverityInfo,
 		},
 		{
-			Name: "Admin Endpoint",
-			Type: DetectorEndpoint,
-			Re:   regexp.MustCompile(`["'](/(?:admin|internal|private)/[^\s"']+)["']`),
+			Name:     "Admin Endpoint",
+			Type:     DetectorEndpoint,
+			Re:       regexp.MustCompile(`["'](/(?:admin|internal|private)/[^\s"']+)["']`),
+			Keywords: []string{"/admin/", "/internal/", "/private/"},
+			Severity: SeverityLow,
 		},
 
 		// Slack
 		{
-			Name: "Slack Webhook",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(https://hooks\.slack\.com/services/T[a-zA-Z0-9_]+/B[a-zA-Z0-9_]+/[a-zA-Z0-9_]+)`),
+			Name:     "Slack Webhook",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(https://hooks\.slack\.com/services/T[a-zA-Z0-9_]+/B[a-zA-Z0-9_]+/[a-zA-Z0-9_]+)`),
+			Keywords: []string{"hooks.slack.com"},
+			Severity: SeverityMedium,
 		},
 		// Updated Slack Token to catch more variants
 		{
-			Name: "Slack Token",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(xox[pborsa]-[0-9]{12}-[0-9]{12}-[0-9]{12}-[a-z0-9]{32})`),
+			Name:     "Slack Token",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(xox[pborsa]-[0-9]{12}-[0-9]{12}-[0-9]{12}-[a-z0-9]{32})`),
+			Keywords: []string{"xox"},
+			Severity: SeverityHigh,
 		},
 
 		// Twilio
 		{
-			Name: "Twilio API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(SK[0-9a-fA-F]{32})`),
+			Name:     "Twilio API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(SK[0-9a-fA-F]{32})`),
+			Keywords: []string{"sk"},
+			Severity: SeverityMedium,
 		},
 
 		// SendGrid
 		{
-			Name: "SendGrid API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(SG\.[a-zA-Z0-9_-]{22}\.[a-zA-Z0-9_-]{43})`),
+			Name:     "SendGrid API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(SG\.[a-zA-Z0-9_-]{22}\.[a-zA-Z0-9_-]{43})`),
+			Keywords: []string{"sg."},
+			Severity: SeverityHigh,
 		},
 
 		// MailGun
 		{
-			Name: "MailGun API Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(key-[0-9a-zA-Z]{32})`),
+			Name:     "MailGun API Key",
+			Type:     DetectorSecret,
+			Re:       regexp.MustCompile(`(key-[0-9a-zA-Z]{32})`),
+			Keywords: []string{"key-"},
+			Severity: SeverityHigh,
 		},
 
-		// SSH Private Key
-		{
-			Name: "SSH Private Key",
-			Type: DetectorSecret,
-			Re:   regexp.MustCompile(`(-----BEGIN (?:RSA|DSA|EC|OPENSSH|PGP) PRIVATE KEY(?: BLOCK)?-----)`),
+		// SSH Private Key: the whole block when its end is found, otherwise
+		// just the header
+		{
+			Name:      "SSH Private Key",
+			Type:      DetectorSecret,
+			Re:        regexp.MustCompile(`(-----BEGIN (?:(?:RSA|DSA|EC|OPENSSH|PGP|ENCRYPTED) )?PRIVATE KEY(?: BLOCK)?-----(?:(?:[A-Za-z0-9+/=\s:,.-]|\\{1,2}[nr])*?-----END (?:(?:RSA|DSA|EC|OPENSSH|PGP|ENCRYPTED) )?PRIVATE KEY(?: BLOCK)?-----)?)`),
+			Keywords:  []string{"private key"},
+			Severity:  SeverityCritical,
+			MultiLine: true,
+			Parse:     parsePrivateKey,
 		},
 	}
 }
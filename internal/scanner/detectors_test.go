@@ -0,0 +1,33 @@
+package scanner
+
+import (
+	"testing"
+
+	"github.com/user/webhog/internal/renderer"
+)
+
+func TestDetectorsWithoutKeyPrefix(t *testing.T) {
+	for _, tc := range []struct {
+		detector, line, token string
+	}{
+		{"Facebook OAuth", `facebook_secret = "3f9a1c7e5b2d8f4a6c0e9b1d7f3a5c8e";`, "3f9a1c7e5b2d8f4a6c0e9b1d7f3a5c8e"},
+		{"GitHub Legacy Token", `github_token = "Aq3Zt9Lm2Xw8Rb5Nc7Vd1Ke6Jf4Hg0Pu2Ys8Tq";`, "Aq3Zt9Lm2Xw8Rb5Nc7Vd1Ke6Jf4Hg0Pu2Ys8Tq"},
+		{"Heroku API Key", `HEROKU_API_KEY = "6f1d2c3b-4a5e-4f60-8b7c-9d0e1f2a3b4c";`, "6f1d2c3b-4a5e-4f60-8b7c-9d0e1f2a3b4c"},
+	} {
+		s := NewScanner(false, 4.5, 20)
+		findings := s.scanBlob(renderer.JSBlob{Source: "external", Path: "app.js", Body: tc.line})
+
+		found := false
+		for _, f := range findings {
+			if f.Detector == tc.detector {
+				found = true
+				if f.Token != tc.token {
+					t.Errorf("%s: got token %q, want %q", tc.detector, f.Token, tc.token)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: no finding in %+v", tc.detector, findings)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// hashedName matches a content hash in a bundled file name, such as
+// main.3f9a2c1b.js and 787.a1b2c3d4.chunk.js (webpack) or index-BvA3xZ_q.js (Vite)
+var hashedName = regexp.MustCompile(`[.-]([A-Za-z0-9_]{8,})(\.(?:chunk\.)?(?:m?js|css|map))`)
+
+// Fingerprint identifies a finding across scans: the same detector matching
+// the same token in the same file has the same fingerprint, even when the
+// file is fetched with a different query string or rebuilt with a new
+// content hash
+func Fingerprint(f Finding) string {
+	token := sha256.Sum256([]byte(f.Token))
+	sum := sha256.Sum256([]byte(f.Detector + "\x00" + NormalizePath(f.Path) + "\x00" + hex.EncodeToString(token[:])))
+	return hex.EncodeToString(sum[:16])
+}
+
+// NormalizePath strips what changes between deployments from a finding's
+// path: the query string and content hashes in file names. Fragments like
+// #inline-1 and source map suffixes like !src/app.ts are kept.
+func NormalizePath(path string) string {
+	base, inner, _ := strings.Cut(path, "!")
+	if u, err := url.Parse(base); err == nil && u.Scheme != "" {
+		u.Scheme = strings.ToLower(u.Scheme)
+		u.Host = strings.ToLower(u.Host)
+		u.RawQuery = ""
+		u.ForceQuery = false
+		base = u.String()
+	}
+
+	base = hashedName.ReplaceAllStringFunc(base, stripHash)
+	if inner != "" {
+		return base + "!" + inner
+	}
+	return base
+}
+
+// stripHash removes the hash from a hashedName match if it looks like one,
+// i.e. contains a digit or an uppercase letter, so names like
+// app-settings.js are left alone
+func stripHash(match string) string {
+	parts := hashedName.FindStringSubmatch(match)
+	if !strings.ContainsAny(parts[1], "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		return match
+	}
+	return parts[2]
+}
@@ -0,0 +1,228 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultIgnoreFile is read from the working directory when it exists
+const DefaultIgnoreFile = ".webhogignore"
+
+// Suppression hides findings that have been reviewed. Every field that is
+// set must match for a finding to be suppressed.
+type Suppression struct {
+	Fingerprint string
+	Detector    string         // Detector name, case-insensitive
+	Path        *regexp.Regexp // Compiled from a glob over the finding path
+	Token       *regexp.Regexp
+	Expires     time.Time // Zero for no expiry; the suppression lapses after this day
+	Reason      string
+	Source      string // file:line the suppression came from
+}
+
+// suppressionSpec is a suppression as written in an ignore file
+type suppressionSpec struct {
+	Fingerprint string `yaml:"fingerprint"`
+	Detector    string `yaml:"detector"`
+	Path        string `yaml:"path"`
+	Token       string `yaml:"token"`
+	Expires     string `yaml:"expires"`
+	Reason      string `yaml:"reason"`
+}
+
+// Matches reports whether the suppression applies to a finding at the
+// given time. Fingerprint must already be set on the finding.
+func (s Suppression) Matches(f Finding, now time.Time) bool {
+	if s.Expired(now) {
+		return false
+	}
+	if s.Fingerprint != "" && s.Fingerprint != f.Fingerprint {
+		return false
+	}
+	if s.Detector != "" && !strings.EqualFold(s.Detector, f.Detector) {
+		return false
+	}
+	if s.Path != nil && !s.Path.MatchString(f.Path) && !s.Path.MatchString(NormalizePath(f.Path)) {
+		return false
+	}
+	if s.Token != nil && !s.Token.MatchString(f.Token) {
+		return false
+	}
+	return true
+}
+
+// Expired reports whether the suppression's expiry date has passed
+func (s Suppression) Expired(now time.Time) bool {
+	return !s.Expires.IsZero() && now.After(s.Expires)
+}
+
+// LoadIgnoreFile reads suppressions from a .yaml/.yml file with a
+// top-level suppressions list, or from a .webhogignore-style file where
+// each line is either a bare fingerprint or key=value pairs separated by
+// semicolons, e.g.
+//
+//	# reviewed public key
+//	detector=Stripe Publishable Key; path=https://example.com/**; expires=2025-12-31
+//	9f86d081884c7d659a2feaa0c55ad015
+func LoadIgnoreFile(path string) ([]Suppression, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading ignore file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return parseIgnoreYAML(path, data)
+	}
+	return parseIgnoreLines(path, data)
+}
+
+// parseIgnoreYAML parses the YAML ignore file format
+func parseIgnoreYAML(path string, data []byte) ([]Suppression, error) {
+	var doc struct {
+		Suppressions []yaml.Node `yaml:"suppressions"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var suppressions []Suppression
+	for _, node := range doc.Suppressions {
+		source := fmt.Sprintf("%s:%d", path, node.Line)
+
+		var spec suppressionSpec
+		if err := node.Decode(&spec); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		s, err := parseSuppression(spec, source)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		suppressions = append(suppressions, s)
+	}
+	return suppressions, nil
+}
+
+// parseIgnoreLines parses the line-based ignore file format
+func parseIgnoreLines(path string, data []byte) ([]Suppression, error) {
+	var suppressions []Suppression
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for lineNum := 1; sc.Scan(); lineNum++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		source := fmt.Sprintf("%s:%d", path, lineNum)
+
+		var spec suppressionSpec
+		if !strings.Contains(line, "=") {
+			spec.Fingerprint = line
+		} else {
+			for _, pair := range strings.Split(line, ";") {
+				if strings.TrimSpace(pair) == "" {
+					continue
+				}
+				key, value, ok := strings.Cut(pair, "=")
+				if !ok {
+					return nil, fmt.Errorf("%s: expected key=value, got %q", source, strings.TrimSpace(pair))
+				}
+				value = strings.TrimSpace(value)
+				switch strings.ToLower(strings.TrimSpace(key)) {
+				case "fingerprint":
+					spec.Fingerprint = value
+				case "detector":
+					spec.Detector = value
+				case "path":
+					spec.Path = value
+				case "token":
+					spec.Token = value
+				case "expires":
+					spec.Expires = value
+				case "reason":
+					spec.Reason = value
+				default:
+					return nil, fmt.Errorf("%s: unknown key %q (want fingerprint, detector, path, token, expires or reason)", source, strings.TrimSpace(key))
+				}
+			}
+		}
+
+		s, err := parseSuppression(spec, source)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		suppressions = append(suppressions, s)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading ignore file: %w", err)
+	}
+	return suppressions, nil
+}
+
+// parseSuppression validates and compiles a suppression
+func parseSuppression(spec suppressionSpec, source string) (Suppression, error) {
+	s := Suppression{
+		Fingerprint: strings.ToLower(spec.Fingerprint),
+		Detector:    spec.Detector,
+		Reason:      spec.Reason,
+		Source:      source,
+	}
+
+	if s.Fingerprint == "" && s.Detector == "" && spec.Path == "" && spec.Token == "" {
+		return Suppression{}, fmt.Errorf("suppression needs a fingerprint, detector, path or token")
+	}
+
+	if spec.Path != "" {
+		s.Path = globRegexp(spec.Path)
+	}
+
+	if spec.Token != "" {
+		re, err := regexp.Compile(spec.Token)
+		if err != nil {
+			return Suppression{}, fmt.Errorf("invalid token regex: %w", err)
+		}
+		s.Token = re
+	}
+
+	if spec.Expires != "" {
+		day, err := time.ParseInLocation("2006-01-02", spec.Expires, time.Local)
+		if err != nil {
+			return Suppression{}, fmt.Errorf("invalid expires %q (want YYYY-MM-DD)", spec.Expires)
+		}
+		// The suppression holds through the whole expiry day
+		s.Expires = day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	return s, nil
+}
+
+// globRegexp compiles a path glob: ** matches anything, * and ? match
+// within a single path segment
+func globRegexp(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,112 @@
+package scanner
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// stringJoin matches what splits a string literal across lines of
+	// source: a concatenation, e.g. "...\n" +\n  "...", or a backslash
+	// continuing the string on the next line
+	stringJoin = regexp.MustCompile("[\"'`]\\s*\\+\\s*[\"'`]|\\\\\r?\n")
+
+	// serviceAccountType matches the type field of a service account key
+	serviceAccountType = regexp.MustCompile(`["']?type["']?\s*:\s*["']service_account["']`)
+
+	// jsonField matches a string field of a JSON or JavaScript object
+	jsonField = regexp.MustCompile(`["']?([A-Za-z_]+)["']?\s*:\s*(?:"((?:[^"\\]|\\.)*)"|'((?:[^'\\]|\\.)*)')`)
+
+	// jsonEscapes undoes the escapes found in PEM keys and emails
+	jsonEscapes = strings.NewReplacer(`\n`, "\n", `\r`, "", `\/`, "/", `\"`, `"`, `\\`, `\`)
+
+	// nestedEscapes undoes one level of escaping of JSON embedded in a
+	// string literal
+	nestedEscapes = strings.NewReplacer(`\\`, `\`, `\"`, `"`)
+)
+
+// firstLine returns text up to its first newline
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
+
+// joinStrings joins string literals split across lines back up, so that
+// multi-line detectors see the value as a whole. The returned map takes
+// offsets in the result back to text, and is nil if nothing was joined.
+func joinStrings(text string) (string, *offsetMap) {
+	joins := stringJoin.FindAllStringIndex(text, -1)
+	if len(joins) == 0 {
+		return text, nil
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	spans := make([]span, 0, len(joins)+1)
+	prev := 0
+	for _, join := range joins {
+		spans = append(spans, span{out: b.Len(), in: prev, n: join[0] - prev})
+		b.WriteString(text[prev:join[0]])
+		prev = join[1]
+	}
+	spans = append(spans, span{out: b.Len(), in: prev, n: len(text) - prev})
+	b.WriteString(text[prev:])
+	return b.String(), &offsetMap{spans: spans}
+}
+
+// parsePrivateKey normalizes a PEM block matched in source code into the
+// key itself: escaped newlines are undone and indentation is dropped
+func parsePrivateKey(match string) (string, map[string]string, bool) {
+	return normalizePEM(match), nil, true
+}
+
+// normalizePEM rebuilds a PEM block from how it appears in source code
+func normalizePEM(text string) string {
+	text = strings.NewReplacer(`\\n`, "\n", `\\r`, "", `\n`, "\n", `\r`, "", "\r", "").Replace(text)
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseServiceAccount extracts the private key of a Google service account
+// key file, with the account's email and project as metadata. Keys without
+// a private key are reported by email, or by their type field.
+func parseServiceAccount(match string) (string, map[string]string, bool) {
+	if strings.Contains(match, `\"`) {
+		match = nestedEscapes.Replace(match)
+	}
+
+	fields := make(map[string]string)
+	for _, m := range jsonField.FindAllStringSubmatch(match, -1) {
+		value := m[2]
+		if value == "" {
+			value = m[3]
+		}
+		if _, ok := fields[m[1]]; !ok {
+			fields[m[1]] = jsonEscapes.Replace(value)
+		}
+	}
+
+	metadata := make(map[string]string)
+	for _, key := range []string{"client_email", "client_id", "project_id", "private_key_id"} {
+		if fields[key] != "" {
+			metadata[key] = fields[key]
+		}
+	}
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+
+	switch {
+	case fields["private_key"] != "":
+		return normalizePEM(fields["private_key"]), metadata, true
+	case fields["client_email"] != "":
+		return fields["client_email"], metadata, true
+	}
+	return serviceAccountType.FindString(match), metadata, true
+}
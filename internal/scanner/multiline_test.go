@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/user/webhog/internal/renderer"
+)
+
+func TestMultiLineJoinsStrings(t *testing.T) {
+	body := `var token = "acme_live_0123456789" +
+  "abcdefghij";
+var cont = "acme_live_9876543210\
+zyxwvutsrq";
+`
+	s := NewScanner(false, 4.5, 20)
+	s.SetDetectors([]Detector{{
+		Name:      "Acme Token",
+		Type:      DetectorSecret,
+		Re:        regexp.MustCompile(`acme_live_[0-9a-z]{20}`),
+		Group:     WholeMatch,
+		MultiLine: true,
+	}})
+	findings := s.scanBlob(renderer.JSBlob{Source: "external", Path: "app.js", Body: body})
+
+	want := []struct {
+		token, source                    string
+		line, column, endLine, endColumn int
+	}{
+		{"acme_live_0123456789abcdefghij", "acme_live_0123456789\" +\n  \"abcdefghij", 1, 14, 2, 14},
+		{"acme_live_9876543210zyxwvutsrq", "acme_live_9876543210\\\nzyxwvutsrq", 3, 13, 4, 11},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("got %d findings, want %d: %+v", len(findings), len(want), findings)
+	}
+	for i, w := range want {
+		f := findings[i]
+		if f.Token != w.token || f.LineNum != w.line || f.Column != w.column || f.EndLine != w.endLine || f.EndColumn != w.endColumn {
+			t.Errorf("finding %d: got %q at %d:%d-%d:%d, want %q at %d:%d-%d:%d", i,
+				f.Token, f.LineNum, f.Column, f.EndLine, f.EndColumn, w.token, w.line, w.column, w.endLine, w.endColumn)
+		}
+		if got := body[f.Start:f.End]; got != w.source {
+			t.Errorf("finding %d: offsets cover %q, want %q", i, got, w.source)
+		}
+	}
+}
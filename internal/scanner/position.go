@@ -0,0 +1,58 @@
+package scanner
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// lineStarts returns the byte offset at which each line of text begins
+func lineStarts(text string) []int {
+	starts := make([]int, 1, strings.Count(text, "\n")+1)
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// lineOf returns the zero-based line holding the byte at offset
+func lineOf(starts []int, offset int) int {
+	return sort.Search(len(starts), func(i int) bool { return starts[i] > offset }) - 1
+}
+
+// lineText returns a line of text without its newline
+func lineText(text string, starts []int, line int) string {
+	end := len(text)
+	if line+1 < len(starts) {
+		end = starts[line+1] - 1
+	}
+	return text[starts[line]:end]
+}
+
+// positions converts byte offsets in a blob into lines and character
+// columns. Offsets on a line are usually converted in increasing order, so
+// counting carries on from the previous one instead of the line start.
+type positions struct {
+	text   string
+	starts []int
+
+	line, offset, column int // The last offset converted
+}
+
+// newPositions returns a converter for text whose lines start at starts
+func newPositions(text string, starts []int) *positions {
+	return &positions{text: text, starts: starts, line: -1}
+}
+
+// at returns the zero-based line and one-based column of a byte offset
+func (p *positions) at(offset int) (int, int) {
+	line := lineOf(p.starts, offset)
+	if line != p.line || offset < p.offset {
+		p.line, p.offset, p.column = line, p.starts[line], 1
+	}
+	p.column += utf8.RuneCountInString(p.text[p.offset:offset])
+	p.offset = offset
+	return line, p.column
+}
@@ -0,0 +1,114 @@
+package scanner
+
+// prefilter finds the detector keywords in a blob with a single
+// Aho-Corasick pass, so each keyword detector only runs its regex on lines
+// that contain one of its keywords. Matching is ASCII case-insensitive.
+type prefilter struct {
+	next    [][256]int32 // DFA transitions per state
+	outputs [][]int      // Detector indexes whose keywords end in each state
+}
+
+// newPrefilter builds the automaton over the keywords of all detectors
+func newPrefilter(detectors []Detector) *prefilter {
+	p := &prefilter{
+		next:    make([][256]int32, 1),
+		outputs: make([][]int, 1),
+	}
+
+	// Build the trie; -1 marks a missing edge until the DFA is completed
+	for i := range p.next[0] {
+		p.next[0][i] = -1
+	}
+	for d, detector := range detectors {
+		for _, kw := range detector.Keywords {
+			if kw == "" {
+				continue
+			}
+			state := int32(0)
+			for i := 0; i < len(kw); i++ {
+				c := lowerASCII(kw[i])
+				if p.next[state][c] == -1 {
+					p.next = append(p.next, [256]int32{})
+					p.outputs = append(p.outputs, nil)
+					for j := range p.next[len(p.next)-1] {
+						p.next[len(p.next)-1][j] = -1
+					}
+					p.next[state][c] = int32(len(p.next) - 1)
+				}
+				state = p.next[state][c]
+			}
+			p.outputs[state] = appendUnique(p.outputs[state], d)
+		}
+	}
+
+	// Breadth-first, fill in missing edges from the failure links and
+	// inherit the outputs of the longest proper suffix
+	fail := make([]int32, len(p.next))
+	queue := make([]int32, 0, len(p.next))
+	for c := range p.next[0] {
+		if s := p.next[0][c]; s == -1 {
+			p.next[0][c] = 0
+		} else {
+			fail[s] = 0
+			queue = append(queue, s)
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for _, d := range p.outputs[fail[state]] {
+			p.outputs[state] = appendUnique(p.outputs[state], d)
+		}
+		for c := range p.next[state] {
+			s := p.next[state][c]
+			if s == -1 {
+				p.next[state][c] = p.next[fail[state]][c]
+				continue
+			}
+			fail[s] = p.next[fail[state]][c]
+			queue = append(queue, s)
+		}
+	}
+
+	return p
+}
+
+// lines returns, for each detector, the ascending indexes of the lines of
+// text that contain one of its keywords. Detectors without keywords, or
+// whose keywords do not appear, get nil.
+func (p *prefilter) lines(text string, detectors int) [][]int {
+	hits := make([][]int, detectors)
+	state := int32(0)
+	line := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c == '\n' {
+			line++
+		}
+		state = p.next[state][lowerASCII(c)]
+		for _, d := range p.outputs[state] {
+			if n := len(hits[d]); n == 0 || hits[d][n-1] != line {
+				hits[d] = append(hits[d], line)
+			}
+		}
+	}
+	return hits
+}
+
+// lowerASCII lowercases an ASCII letter
+func lowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// appendUnique appends v to s unless it is already there
+func appendUnique(s []int, v int) []int {
+	for _, x := range s {
+		if x == v {
+			return s
+		}
+	}
+	return append(s, v)
+}
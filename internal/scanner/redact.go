@@ -0,0 +1,150 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// RedactMode controls how secret values are masked before findings leave
+// the scanner
+type RedactMode string
+
+const (
+	RedactNone    RedactMode = ""
+	RedactFull    RedactMode = "full"    // Replace the value entirely
+	RedactPartial RedactMode = "partial" // Keep a short prefix and suffix
+	RedactHash    RedactMode = "hash"    // Replace the value with a hash of it
+)
+
+// redactedValue replaces a secret under RedactFull
+const redactedValue = "[REDACTED]"
+
+// ParseRedactMode parses a redaction mode name; "none" and the empty
+// string disable redaction
+func ParseRedactMode(s string) (RedactMode, bool) {
+	switch mode := RedactMode(strings.ToLower(strings.TrimSpace(s))); mode {
+	case RedactNone, "none":
+		return RedactNone, true
+	case RedactFull, RedactPartial, RedactHash:
+		return mode, true
+	}
+	return "", false
+}
+
+// Redact masks a secret value according to the mode
+func Redact(value string, mode RedactMode) string {
+	switch mode {
+	case RedactFull:
+		return redactedValue
+	case RedactPartial:
+		// Show at most a quarter of the value, and nothing of short ones
+		keep := len(value) / 8
+		if keep > 4 {
+			keep = 4
+		}
+		if keep == 0 {
+			return "****"
+		}
+		return value[:keep] + "****" + value[len(value)-keep:]
+	case RedactHash:
+		sum := sha256.Sum256([]byte(value))
+		return "sha256:" + hex.EncodeToString(sum[:8])
+	}
+	return value
+}
+
+// blobSecrets are the secrets matched in one blob. Redaction masks them
+// wherever they show up in the blob's findings, including endpoints that
+// embed one, like a URL carrying an API key.
+type blobSecrets struct {
+	spans    [][2]int          // Where the secrets were matched in the blob
+	replacer *strings.Replacer // Masks every secret value
+}
+
+// collectSecrets gathers the secrets among all the findings of a blob,
+// before any are filtered out
+func collectSecrets(findings []Finding, body string, mode RedactMode) *blobSecrets {
+	secrets := &blobSecrets{}
+	values := secretValues(findings, body)
+	pairs := make([]string, 0, 2*len(values))
+	for _, value := range values {
+		pairs = append(pairs, value, Redact(value, mode))
+	}
+	secrets.replacer = strings.NewReplacer(pairs...)
+
+	for _, f := range findings {
+		if f.Type != DetectorEndpoint && f.End > f.Start {
+			secrets.spans = append(secrets.spans, [2]int{f.Start, f.End})
+		}
+	}
+	return secrets
+}
+
+// overlaps reports whether body[start:end] overlaps a secret match
+func (s *blobSecrets) overlaps(start, end int) bool {
+	for _, sp := range s.spans {
+		if start < sp[1] && sp[0] < end {
+			return true
+		}
+	}
+	return false
+}
+
+// minSecretFragment is the shortest value masked in snippets, so that a
+// source line like "{" opening a service account is not masked everywhere
+const minSecretFragment = 8
+
+// secretValues returns the tokens of the non-endpoint findings and the
+// source they were matched in, longest first so that a value containing
+// another is replaced whole. Snippets only show the first line of
+// multi-line values, so that is included too.
+func secretValues(findings []Finding, body string) []string {
+	seen := make(map[string]bool)
+	var values []string
+	add := func(value string) {
+		if len(value) >= minSecretFragment && !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	for _, f := range findings {
+		if f.Type == DetectorEndpoint {
+			continue
+		}
+		if f.Token != "" && !seen[f.Token] {
+			seen[f.Token] = true
+			values = append(values, f.Token)
+		}
+		add(firstLine(f.Token))
+		if 0 <= f.Start && f.Start < f.End && f.End <= len(body) {
+			source := body[f.Start:f.End]
+			add(source)
+			add(firstLine(source))
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	return values
+}
+
+// redactFinding masks the token of a secret finding, and every secret
+// found in the same blob wherever it appears in the token of an endpoint
+// or in any snippet. An endpoint overlapping a secret match that does not
+// contain one verbatim is masked whole. The fingerprint must already be
+// set, since it is computed from the raw token.
+func redactFinding(f *Finding, mode RedactMode, secrets *blobSecrets) {
+	if mode == RedactNone {
+		return
+	}
+	if f.Type != DetectorEndpoint {
+		f.Token = Redact(f.Token, mode)
+	} else if masked := secrets.replacer.Replace(f.Token); masked != f.Token {
+		f.Token = masked
+	} else if secrets.overlaps(f.Start, f.End) {
+		f.Token = Redact(f.Token, mode)
+	}
+	f.Snippet = secrets.replacer.Replace(f.Snippet)
+}
@@ -0,0 +1,275 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ruleFields lists the keys a rule may contain
+var ruleFields = map[string]bool{
+	"name": true, "type": true, "regex": true, "group": true, "keywords": true,
+	"entropy": true, "severity": true, "allowlist": true, "multiline": true,
+}
+
+// ruleSpec is a single detector definition as written in a rules file
+type ruleSpec struct {
+	Name      string   `yaml:"name"`
+	Type      string   `yaml:"type"`
+	Regex     string   `yaml:"regex"`
+	Group     *int     `yaml:"group"`
+	Keywords  []string `yaml:"keywords"`
+	Entropy   float64  `yaml:"entropy"`
+	Severity  string   `yaml:"severity"`
+	Allowlist []string `yaml:"allowlist"`
+	Multiline bool     `yaml:"multiline"`
+}
+
+// RuleSet is a set of custom detectors loaded from a rules file
+type RuleSet struct {
+	ReplaceBuiltin bool       // Drop the built-in detectors instead of extending them
+	Detectors      []Detector // The custom detectors, in file order
+}
+
+// RuleError points at the rule in a rules file that failed validation
+type RuleError struct {
+	File string
+	Line int
+	Rule string // Rule name, or "#N" if it has none
+	Err  error
+}
+
+func (e *RuleError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: rule %s: %v", e.File, e.Rule, e.Err)
+	}
+	return fmt.Sprintf("%s:%d: rule %s: %v", e.File, e.Line, e.Rule, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// LoadRules reads custom detectors from a YAML, JSON or TOML file of the form:
+//
+//	replace_builtin: false
+//	rules:
+//	  - name: Internal Token
+//	    type: secret
+//	    regex: 'acme_([a-z0-9]{32})'
+//	    group: 1
+//	    keywords: [acme_]
+//	    entropy: 3.5
+//	    severity: high
+//	    allowlist: ['^acme_0+$']
+//	    multiline: false
+//
+// Other top-level keys are ignored, so rules can live in the main config file.
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules: %w", err)
+	}
+
+	var doc yaml.Node
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		// TOML has no position information, so re-encode it as YAML and
+		// report rules by name only
+		var raw map[string]interface{}
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		var root yaml.Node
+		if err := root.Encode(raw); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		doc.Content = []*yaml.Node{&root}
+	} else if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	set := &RuleSet{}
+	if len(doc.Content) == 0 {
+		return set, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s:%d: expected a mapping with a \"rules\" list", path, root.Line)
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		switch key.Value {
+		case "replace_builtin":
+			if err := value.Decode(&set.ReplaceBuiltin); err != nil {
+				return nil, fmt.Errorf("%s:%d: replace_builtin: %w", path, value.Line, err)
+			}
+		case "rules":
+			if value.Kind != yaml.SequenceNode {
+				return nil, fmt.Errorf("%s:%d: rules must be a list", path, value.Line)
+			}
+			for n, item := range value.Content {
+				d, err := parseRule(item)
+				if err != nil {
+					name := fmt.Sprintf("#%d", n+1)
+					if spec := ruleName(item); spec != "" {
+						name = fmt.Sprintf("%q", spec)
+					}
+					return nil, &RuleError{File: path, Line: item.Line, Rule: name, Err: err}
+				}
+				set.Detectors = append(set.Detectors, d)
+			}
+		}
+	}
+
+	return set, nil
+}
+
+// LoadDetectors returns the built-in detectors combined with the rules from
+// each file in order. A rule with the same name as an existing detector
+// replaces it.
+func LoadDetectors(paths ...string) ([]Detector, error) {
+	detectors := GetDetectors()
+
+	for _, path := range paths {
+		set, err := LoadRules(path)
+		if err != nil {
+			return nil, err
+		}
+		if set.ReplaceBuiltin {
+			detectors = nil
+		}
+		detectors = mergeDetectors(detectors, set.Detectors)
+	}
+
+	if len(detectors) == 0 {
+		return nil, fmt.Errorf("no detectors left after loading rules")
+	}
+
+	return detectors, nil
+}
+
+// mergeDetectors appends custom detectors, overriding any with the same name
+func mergeDetectors(base, custom []Detector) []Detector {
+	index := make(map[string]int, len(base))
+	merged := append([]Detector(nil), base...)
+	for i, d := range merged {
+		index[d.Name] = i
+	}
+
+	for _, d := range custom {
+		if i, ok := index[d.Name]; ok {
+			merged[i] = d
+			continue
+		}
+		index[d.Name] = len(merged)
+		merged = append(merged, d)
+	}
+
+	return merged
+}
+
+// ruleName extracts the name of a rule node for error messages
+func ruleName(item *yaml.Node) string {
+	if item.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(item.Content); i += 2 {
+		if item.Content[i].Value == "name" {
+			return item.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// parseRule validates a rule node and compiles it into a Detector
+func parseRule(item *yaml.Node) (Detector, error) {
+	if item.Kind != yaml.MappingNode {
+		return Detector{}, fmt.Errorf("expected a mapping")
+	}
+	for i := 0; i < len(item.Content); i += 2 {
+		if key := item.Content[i].Value; !ruleFields[key] {
+			return Detector{}, fmt.Errorf("unknown field %q", key)
+		}
+	}
+
+	var spec ruleSpec
+	if err := item.Decode(&spec); err != nil {
+		return Detector{}, err
+	}
+
+	if strings.TrimSpace(spec.Name) == "" {
+		return Detector{}, fmt.Errorf("name is required")
+	}
+	if spec.Regex == "" {
+		return Detector{}, fmt.Errorf("regex is required")
+	}
+
+	re, err := regexp.Compile(spec.Regex)
+	if err != nil {
+		return Detector{}, fmt.Errorf("invalid regex: %w", err)
+	}
+
+	d := Detector{
+		Name:       spec.Name,
+		Type:       DetectorSecret,
+		Re:         re,
+		MinEntropy: spec.Entropy,
+		MultiLine:  spec.Multiline,
+	}
+
+	if spec.Type != "" {
+		switch t := DetectorType(strings.ToLower(spec.Type)); t {
+		case DetectorSecret, DetectorEndpoint, DetectorConfig, DetectorGeneric:
+			d.Type = t
+		default:
+			return Detector{}, fmt.Errorf("invalid type %q (want secret, endpoint, config or generic)", spec.Type)
+		}
+	}
+
+	// Without a group the token is the first capture group, or the whole
+	// match if there is none; group 0 asks for the whole match
+	switch {
+	case spec.Group == nil && re.NumSubexp() == 0, spec.Group != nil && *spec.Group == 0:
+		d.Group = WholeMatch
+	case spec.Group != nil:
+		if *spec.Group < 0 || *spec.Group > re.NumSubexp() {
+			return Detector{}, fmt.Errorf("group %d out of range: regex has %d capture groups", *spec.Group, re.NumSubexp())
+		}
+		d.Group = *spec.Group
+	}
+
+	if spec.Entropy < 0 {
+		return Detector{}, fmt.Errorf("entropy must not be negative")
+	}
+
+	if spec.Severity != "" {
+		sev, ok := ParseSeverity(spec.Severity)
+		if !ok {
+			return Detector{}, fmt.Errorf("invalid severity %q (want critical, high, medium, low or info)", spec.Severity)
+		}
+		d.Severity = sev
+	}
+
+	for _, kw := range spec.Keywords {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			d.Keywords = append(d.Keywords, kw)
+		}
+	}
+
+	for _, pattern := range spec.Allowlist {
+		allow, err := regexp.Compile(pattern)
+		if err != nil {
+			return Detector{}, fmt.Errorf("invalid allowlist pattern %q: %w", pattern, err)
+		}
+		d.Allowlist = append(d.Allowlist, allow)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,61 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/user/webhog/internal/renderer"
+)
+
+// loadRule loads a rules file holding a single rule with the given regex
+// and extra fields
+func loadRule(t *testing.T, regex, extra string) (Detector, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	rules := "rules:\n  - name: Acme Token\n    regex: '" + regex + "'\n" + extra
+	if err := os.WriteFile(path, []byte(rules), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	set, err := LoadRules(path)
+	if err != nil {
+		return Detector{}, err
+	}
+	return set.Detectors[0], nil
+}
+
+func TestRuleGroup(t *testing.T) {
+	const body = `const token = "acme_0123456789abcdef0123456789abcdef";`
+
+	for _, tc := range []struct {
+		name, regex, extra, token string
+	}{
+		{"groups, no group field", `acme_([0-9a-f]{32})`, "", "0123456789abcdef0123456789abcdef"},
+		{"group 2", `(acme)_([0-9a-f]{32})`, "    group: 2\n", "0123456789abcdef0123456789abcdef"},
+		{"group 0", `acme_([0-9a-f]{32})`, "    group: 0\n", "acme_0123456789abcdef0123456789abcdef"},
+		{"no groups", `acme_[0-9a-f]{32}`, "", "acme_0123456789abcdef0123456789abcdef"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := loadRule(t, tc.regex, tc.extra)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := NewScanner(false, 4.5, 20)
+			s.SetDetectors([]Detector{d})
+			findings := s.scanBlob(renderer.JSBlob{Source: "external", Path: "app.js", Body: body})
+			if len(findings) != 1 || findings[0].Token != tc.token {
+				t.Errorf("got %+v, want one finding of %q", findings, tc.token)
+			}
+		})
+	}
+}
+
+func TestRuleGroupOutOfRange(t *testing.T) {
+	for _, extra := range []string{"    group: 2\n", "    group: -1\n"} {
+		_, err := loadRule(t, `acme_([0-9a-f]{32})`, extra)
+		if err == nil || !strings.Contains(err.Error(), "out of range") {
+			t.Errorf("%q: got error %v, want group out of range", extra, err)
+		}
+	}
+}
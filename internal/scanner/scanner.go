@@ -1,30 +1,124 @@
 package scanner
 
 import (
+	"context"
 	"math"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
+	"github.com/user/webhog/internal/pool"
 	"github.com/user/webhog/internal/renderer"
 )
 
 // Scanner scans rendered pages for secrets and endpoints
 type Scanner struct {
 	detectors      []Detector
+	prefilter      *prefilter
 	includeEntropy bool
 	minEntropy     float64
 	minLength      int
+	pool           *pool.Pool
+	verifier       Verifier
+	minSeverity    Severity
+	suppressions   []Suppression
+	redact         RedactMode
+	decodeDepth    int
+	beautify       bool
+
+	mu         sync.Mutex
+	suppressed map[string]bool // Fingerprints suppressed since the last TakeSuppressed
 }
 
 // NewScanner creates a new scanner with the given configuration
 func NewScanner(includeEntropy bool, minEntropy float64, minLength int) *Scanner {
+	detectors := GetDetectors()
 	return &Scanner{
-		detectors:      GetDetectors(),
+		detectors:      detectors,
+		prefilter:      newPrefilter(detectors),
 		includeEntropy: includeEntropy,
 		minEntropy:     minEntropy,
 		minLength:      minLength,
+		minSeverity:    SeverityInfo,
+		decodeDepth:    DefaultDecodeDepth,
 	}
 }
 
+// SetDetectors replaces the detectors the scanner runs, e.g. with the
+// result of LoadDetectors
+func (s *Scanner) SetDetectors(detectors []Detector) {
+	s.detectors = detectors
+	s.prefilter = newPrefilter(detectors)
+}
+
+// SetPool makes the scanner process blobs concurrently, each holding a slot
+// in the given worker pool
+func (s *Scanner) SetPool(p *pool.Pool) {
+	s.pool = p
+}
+
+// SetVerifier enables live verification of the secrets found in each blob
+func (s *Scanner) SetVerifier(v Verifier) {
+	s.verifier = v
+}
+
+// SetMinSeverity drops findings below the given severity
+func (s *Scanner) SetMinSeverity(sev Severity) {
+	s.minSeverity = sev
+}
+
+// SetSuppressions hides findings matching any of the given suppressions
+func (s *Scanner) SetSuppressions(suppressions []Suppression) {
+	s.suppressions = suppressions
+}
+
+// SetRedact masks secret values in the findings the scanner emits
+func (s *Scanner) SetRedact(mode RedactMode) {
+	s.redact = mode
+}
+
+// SetDecodeDepth sets how many nested encodings, such as URL-encoded text
+// inside base64, are decoded and rescanned; 0 disables decoding
+func (s *Scanner) SetDecodeDepth(depth int) {
+	s.decodeDepth = depth
+}
+
+// SetBeautify makes the scanner pretty-print minified JavaScript before
+// scanning it, so per-line detectors and snippets see one statement at a
+// time. Findings are still positioned in the original blob.
+func (s *Scanner) SetBeautify(beautify bool) {
+	s.beautify = beautify
+}
+
+// TakeSuppressed returns how many distinct findings were suppressed since
+// the last call, and resets the count
+func (s *Scanner) TakeSuppressed() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.suppressed)
+	s.suppressed = nil
+	return n
+}
+
+// suppress reports whether a finding is suppressed, counting it if so
+func (s *Scanner) suppress(f Finding) bool {
+	now := time.Now()
+	for _, sup := range s.suppressions {
+		if sup.Matches(f, now) {
+			s.mu.Lock()
+			if s.suppressed == nil {
+				s.suppressed = make(map[string]bool)
+			}
+			s.suppressed[f.Fingerprint] = true
+			s.mu.Unlock()
+			return true
+		}
+	}
+	return false
+}
+
 // Scan processes a RenderResult and returns all findings
 func (s *Scanner) Scan(result *renderer.RenderResult) []Finding {
 	findingsChan := make(chan Finding)
@@ -33,7 +127,7 @@ func (s *Scanner) Scan(result *renderer.RenderResult) []Finding {
 	// Start streaming in a goroutine
 	go func() {
 		defer close(findingsChan)
-		s.ScanStream(result, findingsChan)
+		s.ScanStream(context.Background(), result, findingsChan)
 	}()
 
 	// Collect findings
@@ -47,93 +141,334 @@ func (s *Scanner) Scan(result *renderer.RenderResult) []Finding {
 	return findings
 }
 
-// ScanStream processes a RenderResult and sends findings to the provided channel
-func (s *Scanner) ScanStream(result *renderer.RenderResult, findingsChan chan<- Finding) {
-	// Scan all JS blobs
-	for _, blob := range result.JSBlobs {
-		blobFindings := s.scanBlob(blob)
-		for _, f := range blobFindings {
-			findingsChan <- f
-		}
-	}
+// ScanStream processes a RenderResult and sends findings to the provided
+// channel. Cancelling ctx stops scanning further blobs and aborts live
+// verification.
+func (s *Scanner) ScanStream(ctx context.Context, result *renderer.RenderResult, findingsChan chan<- Finding) {
+	blobs := result.JSBlobs
 
 	// Also scan the main HTML content
 	if result.HTML != "" {
-		htmlBlob := renderer.JSBlob{
+		blobs = append(blobs[:len(blobs):len(blobs)], renderer.JSBlob{
 			Source: "html",
 			Path:   result.URL,
 			Body:   result.HTML,
+		})
+	}
+
+	// Scan all blobs, bounded by the worker pool
+	s.pool.Each(ctx, len(blobs), func(i int) {
+		found := s.scanBlob(blobs[i])
+
+		// Drop suppressed and minor findings first, so that their secrets
+		// are never sent off for verification
+		var findings []Finding
+		for _, f := range found {
+			f.Fingerprint = Fingerprint(f)
+			if f.Severity.Rank() < s.minSeverity.Rank() || s.suppress(f) {
+				continue
+			}
+			findings = append(findings, f)
 		}
-		htmlFindings := s.scanBlob(htmlBlob)
-		for _, f := range htmlFindings {
+		if s.verifier != nil && len(findings) > 0 {
+			s.verifier.Verify(ctx, findings)
+		}
+
+		// Secrets of dropped findings are still masked wherever they show
+		// up in the ones reported
+		var secrets *blobSecrets
+		if s.redact != RedactNone {
+			secrets = collectSecrets(found, blobs[i].Body, s.redact)
+		}
+		for _, f := range findings {
+			rescoreVerified(&f)
+			if f.Severity.Rank() < s.minSeverity.Rank() {
+				continue
+			}
+			redactFinding(&f, s.redact, secrets)
 			findingsChan <- f
 		}
-	}
+	})
 }
 
-// scanBlob scans a single JavaScript blob for secrets
+// scanBlob scans a single JavaScript blob for secrets, including those
+// hidden in encoded strings
 func (s *Scanner) scanBlob(blob renderer.JSBlob) []Finding {
+	body := blob.Body
+	var offsets *offsetMap
+	if s.beautify && blob.Source != "html" && minified(body) {
+		body, offsets = beautify(body)
+	}
+
+	findings := s.scanText(blob.Path, body, s.includeEntropy)
+	var decoded []Finding
+	if s.decodeDepth > 0 {
+		decoded = s.scanDecoded(blob.Path, body, s.decodeDepth)
+	}
+	if len(decoded) == 0 && offsets == nil {
+		return findings
+	}
+
+	// Decoded findings are positioned at the encoded text in the blob
+	pos := newPositions(body, lineStarts(body))
+	for i := range decoded {
+		decoded[i].locate(body, decoded[i].Start, decoded[i].End, pos)
+	}
+	findings = append(findings, decoded...)
+	if offsets == nil {
+		return findings
+	}
+
+	// Report positions in the original blob, keeping the formatted line
+	pos = newPositions(blob.Body, lineStarts(blob.Body))
+	for i := range findings {
+		f := &findings[i]
+		f.FormattedLine = f.LineNum
+		f.locate(blob.Body, offsets.original(f.Start), offsets.originalEnd(f.End), pos)
+	}
+	return findings
+}
+
+// scanDecoded decodes the encoded strings in text and scans what they
+// decode to, down to depth nested encodings. Findings are positioned at
+// the encoded text within text. Entropy detection is skipped, since an
+// encoded high-entropy string is already one itself.
+func (s *Scanner) scanDecoded(path, text string, depth int) []Finding {
 	var findings []Finding
+	for _, seg := range findEncoded(text) {
+		inner := s.scanText(path, seg.decoded, false)
+		if depth > 1 {
+			inner = append(inner, s.scanDecoded(path, seg.decoded, depth-1)...)
+		}
+
+		for _, f := range inner {
+			// Tokens readable as they are, like the host of a URL-encoded
+			// address, are reported by the plain scan
+			if strings.Contains(text, f.Token) {
+				continue
+			}
+			if f.DecodeChain == "" {
+				f.DecodeChain = seg.encoding
+			} else {
+				f.DecodeChain = seg.encoding + " > " + f.DecodeChain
+			}
+			f.Start, f.End = seg.start, seg.end
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// scanText runs the detectors over text
+func (s *Scanner) scanText(path, body string, entropy bool) []Finding {
+	var findings []Finding
+
+	starts := lineStarts(body)
+	pos := newPositions(body, starts)
+
+	// Lines holding each detector's keywords, and how far each detector
+	// has got through its list
+	hits := s.prefilter.lines(body, len(s.detectors))
+	next := make([]int, len(s.detectors))
 
-	lines := strings.Split(blob.Body, "\n")
+	// Multi-line detectors match across the whole blob, with string
+	// literals split across lines joined back up
+	var joined *positions
+	var offsets *offsetMap
+	for d, detector := range s.detectors {
+		if !detector.MultiLine || (len(detector.Keywords) > 0 && len(hits[d]) == 0) {
+			continue
+		}
+		if joined == nil {
+			text, m := joinStrings(body)
+			joined, offsets = newPositions(text, lineStarts(text)), m
+		}
+		if offsets == nil {
+			findings = append(findings, detector.match(path, body, 0, len(body), pos)...)
+			continue
+		}
+		for _, f := range detector.match(path, joined.text, 0, len(joined.text), joined) {
+			start, end := offsets.original(f.Start), offsets.originalEnd(f.End)
+			line := f.locate(body, start, end, pos)
+			detector.describe(&f, line, start-pos.starts[f.LineNum-1], body[start:end])
+			findings = append(findings, f)
+		}
+	}
+
+	for lineNum := range starts {
+		line := lineText(body, starts, lineNum)
 
-	for lineNum, line := range lines {
-		// Run all detectors on this line
-		for _, detector := range s.detectors {
-			matches := detector.Re.FindAllStringSubmatch(line, -1)
-			for _, match := range matches {
-				if len(match) < 2 {
+		// Run the other detectors on this line
+		for d, detector := range s.detectors {
+			if detector.MultiLine {
+				continue
+			}
+			if len(detector.Keywords) > 0 {
+				if next[d] >= len(hits[d]) || hits[d][next[d]] != lineNum {
 					continue
 				}
-
-				token := match[1]
-				snippet := createSnippet(line, token)
-
-				findings = append(findings, Finding{
-					Detector: detector.Name,
-					Type:     detector.Type,
-					Path:     blob.Path,
-					LineNum:  lineNum + 1,
-					Snippet:  snippet,
-					Token:    token,
-				})
+				next[d]++
 			}
+			findings = append(findings, detector.match(path, body, starts[lineNum], starts[lineNum]+len(line), pos)...)
 		}
 
 		// Optional: Entropy-based detection
-		if s.includeEntropy {
-			findings = append(findings, s.detectHighEntropy(blob.Path, lineNum+1, line)...)
+		if entropy {
+			findings = append(findings, s.detectHighEntropy(path, line, starts[lineNum], pos)...)
 		}
 	}
 
 	return findings
 }
 
-// detectHighEntropy finds high-entropy strings that might be secrets
-func (s *Scanner) detectHighEntropy(path string, lineNum int, line string) []Finding {
+// maxParsedSnippet is how much of the source of a parsed token its
+// snippet shows
+const maxParsedSnippet = 40
+
+// match runs the detector over body[start:end]
+func (d *Detector) match(path, body string, start, end int, pos *positions) []Finding {
+	var findings []Finding
+	text := body[start:end]
+	for _, loc := range d.Re.FindAllStringSubmatchIndex(text, -1) {
+		from, to, ok := d.extract(loc)
+		if !ok {
+			continue
+		}
+
+		source := text[from:to]
+		token := source
+		var metadata map[string]string
+		if d.Parse != nil {
+			if token, metadata, ok = d.Parse(token); !ok {
+				continue
+			}
+		}
+		if !d.accept(token) {
+			continue
+		}
+
+		f := Finding{
+			Detector: d.Name,
+			Type:     d.Type,
+			Path:     path,
+			Token:    token,
+			Metadata: metadata,
+		}
+		line := f.locate(body, start+from, start+to, pos)
+		d.describe(&f, line, start+from-pos.starts[f.LineNum-1], source)
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// describe sets the snippet and score of a finding whose source text
+// starts at col of line
+func (d *Detector) describe(f *Finding, line string, col int, source string) {
+	// The source of a parsed token may be long, escaped or span lines, so
+	// only its start is shown
+	if f.Token != source {
+		end := min(len(line), col+maxParsedSnippet)
+		f.Snippet = createSnippet(line[:end], col, end)
+		if end < len(line) {
+			f.Snippet += "..."
+		}
+	} else {
+		f.Snippet = createSnippet(line, col, col+len(source))
+	}
+	score(f, d.Severity, line[:col])
+}
+
+// locate records the position of the token at body[start:end] on the
+// finding, and returns the line it starts on
+func (f *Finding) locate(body string, start, end int, pos *positions) string {
+	line, col := pos.at(start)
+	endLine, endCol := pos.at(end)
+
+	f.Start, f.End = start, end
+	f.LineNum, f.Column = line+1, col
+	f.EndLine, f.EndColumn = endLine+1, endCol
+	return lineText(body, pos.starts, line)
+}
+
+// extract returns the bounds of the token in a regex match, using the
+// detector's capture group. A detector without one finds nothing unless it
+// takes the whole match.
+func (d *Detector) extract(loc []int) (int, int, bool) {
+	group := d.Group
+	switch group {
+	case WholeMatch:
+		group = 0
+	case 0:
+		group = 1
+	}
+	if 2*group+1 >= len(loc) || loc[2*group] < 0 || loc[2*group] == loc[2*group+1] {
+		return 0, 0, false
+	}
+	return loc[2*group], loc[2*group+1], true
+}
+
+// accept applies the detector's entropy threshold and allowlist to a token
+func (d *Detector) accept(token string) bool {
+	if d.MinEntropy > 0 && calculateEntropy(token) < d.MinEntropy {
+		return false
+	}
+	for _, allow := range d.Allowlist {
+		if allow.MatchString(token) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAny reports whether the lowercased text contains any keyword
+func containsAny(lowerText string, keywords []string) bool {
+	for _, kw := range keywords {
+		if strings.Contains(lowerText, strings.ToLower(kw)) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectHighEntropy finds high-entropy strings that might be secrets on
+// a line starting at byte offset start of the blob
+func (s *Scanner) detectHighEntropy(path, line string, start int, pos *positions) []Finding {
 	var findings []Finding
 
 	// Tokenize the line (simple word splitting)
-	tokens := strings.FieldsFunc(line, func(r rune) bool {
-		return r == ' ' || r == '\t' || r == '"' || r == '\'' || r == ',' || r == ';'
-	})
+	isSeparator := func(c byte) bool {
+		return c == ' ' || c == '\t' || c == '"' || c == '\'' || c == ',' || c == ';'
+	}
+
+	for i := 0; i < len(line); {
+		if isSeparator(line[i]) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(line) && !isSeparator(line[j]) {
+			j++
+		}
+		token := line[i:j]
+		from := i
+		i = j
 
-	for _, token := range tokens {
 		if len(token) < s.minLength {
 			continue
 		}
 
 		entropy := calculateEntropy(token)
 		if entropy >= s.minEntropy {
-			snippet := createSnippet(line, token)
-			findings = append(findings, Finding{
+			f := Finding{
 				Detector: "High Entropy String",
 				Type:     DetectorGeneric,
 				Path:     path,
-				LineNum:  lineNum,
-				Snippet:  snippet,
+				Snippet:  createSnippet(line, from, j),
 				Token:    token,
-			})
+			}
+			f.locate(pos.text, start+from, start+j, pos)
+			score(&f, SeverityLow, line[:from])
+			findings = append(findings, f)
 		}
 	}
 
@@ -162,33 +497,36 @@ func calculateEntropy(s string) float64 {
 	return entropy
 }
 
-// createSnippet creates a context snippet around a token
-func createSnippet(line, token string) string {
-	const maxLen = 100
+// createSnippet creates a context snippet around the token at
+// line[start:end]; a token running on past the line is cut at its end
+func createSnippet(line string, start, end int) string {
+	const context = 20
 
-	idx := strings.Index(line, token)
-	if idx == -1 {
-		if len(line) > maxLen {
-			return line[:maxLen] + "..."
-		}
-		return line
+	if end > len(line) {
+		end = len(line)
 	}
 
-	start := idx - 20
-	if start < 0 {
-		start = 0
+	from := start - context
+	if from < 0 {
+		from = 0
+	}
+	for from > 0 && !utf8.RuneStart(line[from]) {
+		from--
 	}
 
-	end := idx + len(token) + 20
-	if end > len(line) {
-		end = len(line)
+	to := end + context
+	if to > len(line) {
+		to = len(line)
+	}
+	for to < len(line) && !utf8.RuneStart(line[to]) {
+		to++
 	}
 
-	snippet := line[start:end]
-	if start > 0 {
+	snippet := line[from:to]
+	if from > 0 {
 		snippet = "..." + snippet
 	}
-	if end < len(line) {
+	if to < len(line) {
 		snippet = snippet + "..."
 	}
 
@@ -201,8 +539,9 @@ func deduplicateFindings(findings []Finding) []Finding {
 	var unique []Finding
 
 	for _, f := range findings {
-		// Create a unique key based on detector, path, and token
-		key := f.Detector + "|" + f.Path + "|" + f.Token
+		// Create a unique key based on path and fingerprint, which covers
+		// the detector and the token even once it is redacted
+		key := f.Path + "|" + f.Fingerprint
 		if !seen[key] {
 			seen[key] = true
 			unique = append(unique, f)
@@ -0,0 +1,142 @@
+package scanner
+
+import (
+	"sort"
+	"strings"
+)
+
+// testValues are fragments of documentation examples and placeholders;
+// tokens containing one are almost never real credentials
+var testValues = []string{
+	"example",
+	"sample",
+	"dummy",
+	"placeholder",
+	"changeme",
+	"redacted",
+	"your_",
+	"your-",
+	"xxxxxxxx",
+	"abcdefghij",
+	"0123456789",
+	"1234567890",
+	"00000000",
+	"test",
+	"fake",
+}
+
+// contextKeywords suggest that a nearby value is a credential
+var contextKeywords = []string{
+	"key",
+	"secret",
+	"token",
+	"passw",
+	"auth",
+	"credential",
+	"private",
+}
+
+// keywordWindow is how far before a token a context keyword may appear
+const keywordWindow = 40
+
+// DefaultSeverity is used for detectors of type t that don't set one
+func DefaultSeverity(t DetectorType) Severity {
+	switch t {
+	case DetectorEndpoint:
+		return SeverityInfo
+	case DetectorGeneric:
+		return SeverityLow
+	}
+	return SeverityMedium
+}
+
+// score sets the severity and confidence of a new finding from its
+// detector and the text before it on its line
+func score(f *Finding, detector Severity, before string) {
+	f.Severity = detector
+	if f.Severity == "" {
+		f.Severity = DefaultSeverity(f.Type)
+	}
+
+	// Endpoints are what they look like; only secrets need weighing up
+	if f.Type == DetectorEndpoint {
+		f.Confidence = ConfidenceHigh
+		return
+	}
+
+	points := 0
+	if f.Type == DetectorGeneric {
+		points--
+	}
+
+	entropy := calculateEntropy(f.Token)
+	switch {
+	case entropy >= 3.5:
+		points++
+	case entropy < 3:
+		points--
+	}
+
+	if keywordNear(before) {
+		points++
+	}
+
+	if isTestValue(f.Token) {
+		points -= 2
+		if f.Severity.Rank() > SeverityLow.Rank() {
+			f.Severity = SeverityLow
+		}
+	}
+
+	switch {
+	case points >= 1:
+		f.Confidence = ConfidenceHigh
+	case points == 0:
+		f.Confidence = ConfidenceMedium
+	default:
+		f.Confidence = ConfidenceLow
+	}
+}
+
+// rescoreVerified adjusts a finding for the outcome of live verification:
+// live credentials are critical, dead ones are only worth a look
+func rescoreVerified(f *Finding) {
+	if f.Verified == nil {
+		return
+	}
+	if *f.Verified {
+		f.Severity = SeverityCritical
+		f.Confidence = ConfidenceHigh
+		return
+	}
+	if f.Severity.Rank() > SeverityLow.Rank() {
+		f.Severity = SeverityLow
+	}
+}
+
+// keywordNear reports whether a context keyword appears shortly before
+// the token, given the text preceding it on its line
+func keywordNear(before string) bool {
+	start := len(before) - keywordWindow
+	if start < 0 {
+		start = 0
+	}
+	return containsAny(strings.ToLower(before[start:]), contextKeywords)
+}
+
+// isTestValue reports whether a token looks like an example or placeholder
+func isTestValue(token string) bool {
+	return containsAny(strings.ToLower(token), testValues)
+}
+
+// SortFindings orders findings by descending severity, then confidence,
+// keeping the original order otherwise
+func SortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.Severity != b.Severity {
+			return a.Severity.Rank() > b.Severity.Rank()
+		}
+		return a.Confidence.Rank() > b.Confidence.Rank()
+	})
+}
@@ -1,6 +1,11 @@
 package scanner
 
-import "regexp"
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
 
 // DetectorType categorizes the type of finding
 type DetectorType string
@@ -12,19 +17,136 @@ const (
 	DetectorGeneric  DetectorType = "generic"
 )
 
+// Severity ranks how urgently a finding needs attention
+type Severity string
+
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+	SeverityInfo     Severity = "info"
+)
+
+// ParseSeverity parses a severity name, case-insensitively
+func ParseSeverity(s string) (Severity, bool) {
+	switch sev := Severity(strings.ToLower(strings.TrimSpace(s))); sev {
+	case SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo:
+		return sev, true
+	}
+	return "", false
+}
+
+// Rank orders severities from info (0) to critical (4); unknown ones rank
+// below info
+func (s Severity) Rank() int {
+	switch s {
+	case SeverityCritical:
+		return 4
+	case SeverityHigh:
+		return 3
+	case SeverityMedium:
+		return 2
+	case SeverityLow:
+		return 1
+	case SeverityInfo:
+		return 0
+	}
+	return -1
+}
+
+// Confidence is how likely a finding is to be a real, usable value rather
+// than a false positive or placeholder
+type Confidence string
+
+const (
+	ConfidenceHigh   Confidence = "high"
+	ConfidenceMedium Confidence = "medium"
+	ConfidenceLow    Confidence = "low"
+)
+
+// Rank orders confidences from low (0) to high (2)
+func (c Confidence) Rank() int {
+	switch c {
+	case ConfidenceHigh:
+		return 2
+	case ConfidenceMedium:
+		return 1
+	}
+	return 0
+}
+
+// WholeMatch is the Detector.Group that takes the whole regex match as
+// the token
+const WholeMatch = -1
+
 // Detector represents a pattern-based detector
 type Detector struct {
-	Name string
-	Type DetectorType
-	Re   *regexp.Regexp
+	Name       string
+	Type       DetectorType
+	Re         *regexp.Regexp
+	Group      int              // Capture group holding the token: 0 for the first, WholeMatch for the whole match
+	Keywords   []string         // If set, only lines containing one of these (case-insensitive) are matched
+	MinEntropy float64          // If set, tokens below this Shannon entropy are ignored
+	Severity   Severity         // Default severity of findings
+	Allowlist  []*regexp.Regexp // Tokens matching any of these are ignored
+	MultiLine  bool             // Match across the whole blob instead of line by line
+
+	// Parse, if set, turns the matched text into the reported token and
+	// metadata, or rejects the match
+	Parse func(match string) (token string, metadata map[string]string, ok bool)
 }
 
 // Finding represents a discovered secret or endpoint
 type Finding struct {
 	Detector string       `json:"detector"`
 	Type     DetectorType `json:"type"`
-	Path     string       `json:"path"`     // JS file or inline location
-	LineNum  int          `json:"line"`     // Line number in the blob
-	Snippet  string       `json:"snippet"`  // Context around the match
-	Token    string       `json:"token"`    // The actual match
+	Path     string       `json:"path"`    // JS file or inline location
+	LineNum  int          `json:"line"`    // Line number in the blob
+	Snippet  string       `json:"snippet"` // Context around the match
+	Token    string       `json:"token"`   // The actual match
+
+	// Where the token was matched in the blob; for detectors that parse
+	// their match, such as PEM keys, this is the source text. Offsets are
+	// always written, as a match can start at byte 0; End is 0 only in
+	// results from before offsets were recorded.
+	Start     int `json:"start"`                // Byte offset of the first byte
+	End       int `json:"end"`                  // Byte offset just past the last byte
+	Column    int `json:"column,omitempty"`     // Column of Start on LineNum, in characters from 1
+	EndLine   int `json:"end_line,omitempty"`   // Line of End
+	EndColumn int `json:"end_column,omitempty"` // Column of End, just past the token
+
+	// Line of the token in the blob as beautified for scanning, when it was
+	FormattedLine int `json:"formatted_line,omitempty"`
+
+	// Set when the token was found in decoded content, e.g. "base64 > url"
+	// for a URL-encoded secret inside base64. The position is then that of
+	// the outermost encoded text and the snippet is from the decoded text.
+	DecodeChain string `json:"decode_chain,omitempty"`
+
+	Severity    Severity   `json:"severity"`
+	Confidence  Confidence `json:"confidence"`
+	Fingerprint string     `json:"fingerprint"` // Identifies the finding across scans
+
+	// Set by the verification stage, when enabled
+	Verified          *bool             `json:"verified,omitempty"`           // nil when not checked
+	VerificationError string            `json:"verification_error,omitempty"` // Why the check was inconclusive
+	Metadata          map[string]string `json:"metadata,omitempty"`           // Account details from the provider or the parsed key
+}
+
+// Location returns where the finding is as path:line, or path:line:column
+// when the column is known
+func (f Finding) Location() string {
+	if f.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d", f.Path, f.LineNum, f.Column)
+	}
+	return fmt.Sprintf("%s:%d", f.Path, f.LineNum)
+}
+
+// Verifier checks whether the secrets found in a blob are live, recording
+// the outcome on the findings in place. It sees all findings from one blob
+// at a time so that related credentials, like an AWS key ID and its secret,
+// can be checked together. Checks stop early once ctx is cancelled.
+type Verifier interface {
+	Verify(ctx context.Context, findings []Finding)
 }
@@ -0,0 +1,177 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/user/webhog/internal/scanner"
+)
+
+// HistoryFilter selects scanned targets. Zero fields match everything.
+type HistoryFilter struct {
+	Target string    // Substring of the target URL
+	Since  time.Time // Scans finished at or after this time
+	Until  time.Time // Scans finished before this time
+	Limit  int
+}
+
+// HistoryEntry is one target of a past scan
+type HistoryEntry struct {
+	ScanID       int64     `json:"scan_id"`
+	Time         time.Time `json:"time"`
+	Version      string    `json:"version"`
+	URL          string    `json:"url"`
+	FinalURL     string    `json:"final_url"`
+	Pages        int       `json:"pages"`
+	JSBlobs      int       `json:"js_blobs"`
+	Findings     int       `json:"findings"`
+	Technologies []string  `json:"technologies"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// FindingFilter selects stored findings. Zero fields match everything.
+type FindingFilter struct {
+	Target   string    // Substring of the target URL
+	Detector string    // Detector name, case-insensitive
+	Status   string    // StatusOpen or StatusResolved
+	Since    time.Time // Findings last seen at or after this time
+	Until    time.Time // Findings first seen before this time
+	Limit    int
+}
+
+// StoredFinding is a finding tracked across scans of its target
+type StoredFinding struct {
+	scanner.Finding
+	Target     string     `json:"target"`
+	Status     string     `json:"status"`
+	FirstSeen  time.Time  `json:"first_seen"`
+	LastSeen   time.Time  `json:"last_seen"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
+// History lists scanned targets, newest first
+func (s *Store) History(ctx context.Context, filter HistoryFilter) ([]HistoryEntry, error) {
+	var where []string
+	var args []interface{}
+	if filter.Target != "" {
+		where = append(where, `t.url LIKE ?`)
+		args = append(args, "%"+filter.Target+"%")
+	}
+	if !filter.Since.IsZero() {
+		where = append(where, `s.finished_at >= ?`)
+		args = append(args, filter.Since.UTC().Format(timeFormat))
+	}
+	if !filter.Until.IsZero() {
+		where = append(where, `s.finished_at < ?`)
+		args = append(args, filter.Until.UTC().Format(timeFormat))
+	}
+
+	query := `SELECT t.id, s.id, s.finished_at, s.version, t.url, t.final_url, t.pages, t.js_blobs, t.findings, t.error,
+	                 COALESCE((SELECT group_concat(name, char(31)) FROM technologies WHERE target_id = t.id), '')
+	          FROM targets t JOIN scans s ON s.id = t.scan_id` +
+		whereClause(where) + ` ORDER BY s.finished_at DESC, t.id DESC` + limitClause(filter.Limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []HistoryEntry
+	for rows.Next() {
+		var e HistoryEntry
+		var targetID int64
+		var finished, tech string
+		if err := rows.Scan(&targetID, &e.ScanID, &finished, &e.Version, &e.URL, &e.FinalURL,
+			&e.Pages, &e.JSBlobs, &e.Findings, &e.Error, &tech); err != nil {
+			return nil, fmt.Errorf("reading history: %w", err)
+		}
+		e.Time, _ = time.Parse(timeFormat, finished)
+		if tech != "" {
+			e.Technologies = strings.Split(tech, "\x1f")
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Findings lists tracked findings, most recently seen and most severe first
+func (s *Store) Findings(ctx context.Context, filter FindingFilter) ([]StoredFinding, error) {
+	var where []string
+	var args []interface{}
+	if filter.Target != "" {
+		where = append(where, `target LIKE ?`)
+		args = append(args, "%"+filter.Target+"%")
+	}
+	if filter.Detector != "" {
+		where = append(where, `detector = ? COLLATE NOCASE`)
+		args = append(args, filter.Detector)
+	}
+	if filter.Status != "" {
+		where = append(where, `status = ?`)
+		args = append(args, filter.Status)
+	}
+	if !filter.Since.IsZero() {
+		where = append(where, `last_seen >= ?`)
+		args = append(args, filter.Since.UTC().Format(timeFormat))
+	}
+	if !filter.Until.IsZero() {
+		where = append(where, `first_seen < ?`)
+		args = append(args, filter.Until.UTC().Format(timeFormat))
+	}
+
+	query := `SELECT target, fingerprint, detector, type, severity, confidence, path, line, token, snippet,
+	                 verified, status, first_seen, last_seen, resolved_at
+	          FROM findings` + whereClause(where) + `
+	          ORDER BY last_seen DESC, CASE severity
+	              WHEN 'critical' THEN 0 WHEN 'high' THEN 1 WHEN 'medium' THEN 2 WHEN 'low' THEN 3 ELSE 4
+	          END, id` + limitClause(filter.Limit)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying findings: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []StoredFinding
+	for rows.Next() {
+		var f StoredFinding
+		var verified sql.NullBool
+		var firstSeen, lastSeen string
+		var resolvedAt sql.NullString
+		if err := rows.Scan(&f.Target, &f.Fingerprint, &f.Detector, &f.Type, &f.Severity, &f.Confidence,
+			&f.Path, &f.LineNum, &f.Token, &f.Snippet, &verified, &f.Status, &firstSeen, &lastSeen, &resolvedAt); err != nil {
+			return nil, fmt.Errorf("reading findings: %w", err)
+		}
+		if verified.Valid {
+			f.Verified = &verified.Bool
+		}
+		f.FirstSeen, _ = time.Parse(timeFormat, firstSeen)
+		f.LastSeen, _ = time.Parse(timeFormat, lastSeen)
+		if resolvedAt.Valid {
+			t, _ := time.Parse(timeFormat, resolvedAt.String)
+			f.ResolvedAt = &t
+		}
+		findings = append(findings, f)
+	}
+	return findings, rows.Err()
+}
+
+// whereClause joins filter conditions into a WHERE clause
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
+
+// limitClause returns a LIMIT clause, or nothing for no limit
+func limitClause(limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(" LIMIT %d", limit)
+}
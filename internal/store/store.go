@@ -0,0 +1,242 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/user/webhog/internal/ui"
+	_ "modernc.org/sqlite"
+)
+
+// Finding statuses
+const (
+	StatusOpen     = "open"     // Seen in the latest successful scan of its target
+	StatusResolved = "resolved" // Missing from a later successful scan of its target
+)
+
+// timeFormat is how timestamps are stored; it sorts lexically
+const timeFormat = time.RFC3339
+
+const schema = `
+CREATE TABLE IF NOT EXISTS scans (
+	id          INTEGER PRIMARY KEY,
+	started_at  TEXT NOT NULL,
+	finished_at TEXT NOT NULL,
+	version     TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS targets (
+	id        INTEGER PRIMARY KEY,
+	scan_id   INTEGER NOT NULL REFERENCES scans(id) ON DELETE CASCADE,
+	url       TEXT NOT NULL,
+	final_url TEXT NOT NULL,
+	pages     INTEGER NOT NULL,
+	js_blobs  INTEGER NOT NULL,
+	findings  INTEGER NOT NULL,
+	error     TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS targets_url ON targets(url);
+
+CREATE TABLE IF NOT EXISTS technologies (
+	target_id INTEGER NOT NULL REFERENCES targets(id) ON DELETE CASCADE,
+	name      TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS pages (
+	id        INTEGER PRIMARY KEY,
+	target_id INTEGER NOT NULL REFERENCES targets(id) ON DELETE CASCADE,
+	url       TEXT NOT NULL,
+	depth     INTEGER NOT NULL,
+	js_blobs  INTEGER NOT NULL,
+	error     TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS blobs (
+	page_id INTEGER NOT NULL REFERENCES pages(id) ON DELETE CASCADE,
+	source  TEXT NOT NULL,
+	path    TEXT NOT NULL,
+	sha256  TEXT NOT NULL,
+	size    INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS blobs_sha256 ON blobs(sha256);
+
+CREATE TABLE IF NOT EXISTS findings (
+	id            INTEGER PRIMARY KEY,
+	target        TEXT NOT NULL,
+	fingerprint   TEXT NOT NULL,
+	detector      TEXT NOT NULL,
+	type          TEXT NOT NULL,
+	severity      TEXT NOT NULL,
+	confidence    TEXT NOT NULL,
+	path          TEXT NOT NULL,
+	line          INTEGER NOT NULL,
+	token         TEXT NOT NULL,
+	snippet       TEXT NOT NULL,
+	verified      INTEGER,
+	status        TEXT NOT NULL,
+	first_seen    TEXT NOT NULL,
+	last_seen     TEXT NOT NULL,
+	resolved_at   TEXT,
+	first_scan_id INTEGER NOT NULL,
+	last_scan_id  INTEGER NOT NULL,
+	UNIQUE (target, fingerprint)
+);
+CREATE INDEX IF NOT EXISTS findings_detector ON findings(detector);
+`
+
+// Store is an SQLite database of scans and the findings they produced
+type Store struct {
+	db *sql.DB
+}
+
+// DefaultPath returns the default database location,
+// ~/.local/share/webhog/webhog.db (or under $XDG_DATA_HOME when set)
+func DefaultPath() string {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "webhog", "webhog.db")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "webhog.db"
+	}
+	return filepath.Join(home, ".local", "share", "webhog", "webhog.db")
+}
+
+// Open opens the database at path, creating it and its schema if needed
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating database directory: %w", err)
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating schema in %s: %w", path, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SaveScan records a finished scan. Findings are merged with those from
+// earlier scans of the same target: ones seen again stay open, and open
+// ones missing from a successful scan of their target are resolved.
+func (s *Store) SaveScan(ctx context.Context, started, finished time.Time, version string, targets []ui.Target) (int64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO scans (started_at, finished_at, version) VALUES (?, ?, ?)`,
+		started.UTC().Format(timeFormat), finished.UTC().Format(timeFormat), version)
+	if err != nil {
+		return 0, fmt.Errorf("saving scan: %w", err)
+	}
+	scanID, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("saving scan: %w", err)
+	}
+
+	seen := finished.UTC().Format(timeFormat)
+	for _, t := range targets {
+		if err := saveTarget(ctx, tx, scanID, seen, t); err != nil {
+			return 0, fmt.Errorf("saving %s: %w", t.URL, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing scan: %w", err)
+	}
+	return scanID, nil
+}
+
+// saveTarget records one target of a scan with its pages and findings
+func saveTarget(ctx context.Context, tx *sql.Tx, scanID int64, seen string, t ui.Target) error {
+	res, err := tx.ExecContext(ctx,
+		`INSERT INTO targets (scan_id, url, final_url, pages, js_blobs, findings, error)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		scanID, t.URL, t.FinalURL(), len(t.Pages()), t.JSBlobs(), len(t.Findings), t.Error)
+	if err != nil {
+		return err
+	}
+	targetID, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range t.Technologies {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO technologies (target_id, name) VALUES (?, ?)`, targetID, name); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range t.Pages() {
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO pages (target_id, url, depth, js_blobs, error) VALUES (?, ?, ?, ?, ?)`,
+			targetID, p.URL, p.Depth, p.JSBlobs, p.Error)
+		if err != nil {
+			return err
+		}
+		pageID, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		for _, b := range p.Blobs {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO blobs (page_id, source, path, sha256, size) VALUES (?, ?, ?, ?, ?)`,
+				pageID, b.Source, b.Path, b.SHA256, b.Size); err != nil {
+				return err
+			}
+		}
+	}
+
+	// A failed scan says nothing about which findings are gone
+	if t.Error != "" {
+		return nil
+	}
+
+	for _, f := range t.Findings {
+		var verified interface{}
+		if f.Verified != nil {
+			verified = *f.Verified
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO findings (target, fingerprint, detector, type, severity, confidence, path, line,
+			                       token, snippet, verified, status, first_seen, last_seen, first_scan_id, last_scan_id)
+			 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			 ON CONFLICT (target, fingerprint) DO UPDATE SET
+			     severity = excluded.severity,
+			     confidence = excluded.confidence,
+			     line = excluded.line,
+			     snippet = excluded.snippet,
+			     verified = excluded.verified,
+			     status = excluded.status,
+			     last_seen = excluded.last_seen,
+			     resolved_at = NULL,
+			     last_scan_id = excluded.last_scan_id`,
+			t.URL, f.Fingerprint, f.Detector, f.Type, f.Severity, f.Confidence, f.Path, f.LineNum,
+			f.Token, f.Snippet, verified, StatusOpen, seen, seen, scanID, scanID); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE findings SET status = ?, resolved_at = ?
+		 WHERE target = ? AND status = ? AND last_scan_id <> ?`,
+		StatusResolved, seen, t.URL, StatusOpen, scanID)
+	return err
+}
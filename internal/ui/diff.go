@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/user/webhog/internal/scanner"
+)
+
+// Diff compares the findings of two scans by fingerprint
+type Diff struct {
+	New        []scanner.Finding `json:"new"`        // Only in the newer scan
+	Resolved   []scanner.Finding `json:"resolved"`   // Only in the older scan
+	Persisting []scanner.Finding `json:"persisting"` // In both scans
+}
+
+// Compare diffs the findings of an older and a newer scan. Fingerprints
+// missing from results saved by older versions are computed.
+func Compare(older, newer []Target) Diff {
+	oldFindings := fingerprinted(allFindings(older))
+	newFindings := fingerprinted(allFindings(newer))
+
+	inOld := make(map[string]bool, len(oldFindings))
+	for _, f := range oldFindings {
+		inOld[f.Fingerprint] = true
+	}
+	inNew := make(map[string]bool, len(newFindings))
+	for _, f := range newFindings {
+		inNew[f.Fingerprint] = true
+	}
+
+	d := Diff{
+		New:        []scanner.Finding{},
+		Resolved:   []scanner.Finding{},
+		Persisting: []scanner.Finding{},
+	}
+	for _, f := range newFindings {
+		if inOld[f.Fingerprint] {
+			d.Persisting = append(d.Persisting, f)
+		} else {
+			d.New = append(d.New, f)
+		}
+	}
+	for _, f := range oldFindings {
+		if !inNew[f.Fingerprint] {
+			d.Resolved = append(d.Resolved, f)
+		}
+	}
+
+	scanner.SortFindings(d.New)
+	scanner.SortFindings(d.Resolved)
+	scanner.SortFindings(d.Persisting)
+	return d
+}
+
+// fingerprinted returns the findings with their fingerprints, keeping only
+// the first finding for each
+func fingerprinted(findings []scanner.Finding) []scanner.Finding {
+	seen := make(map[string]bool)
+	var unique []scanner.Finding
+	for _, f := range findings {
+		// Results from before fingerprints were recorded need them
+		// computed; redacted results keep the ones computed from the
+		// raw token
+		if f.Fingerprint == "" {
+			f.Fingerprint = scanner.Fingerprint(f)
+		}
+		if !seen[f.Fingerprint] {
+			seen[f.Fingerprint] = true
+			unique = append(unique, f)
+		}
+	}
+	return unique
+}
+
+// OutputDiff writes a diff as JSON or text. Persisting findings are only
+// counted in text output unless all is set.
+func (o *Outputter) OutputDiff(w io.Writer, d Diff, all bool) error {
+	if o.format == FormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(d)
+	}
+
+	o.outputDiffSection(w, "NEW", d.New, secretStyle)
+	o.outputDiffSection(w, "RESOLVED", d.Resolved, endpointStyle)
+	if all {
+		o.outputDiffSection(w, "PERSISTING", d.Persisting, genericStyle)
+	}
+
+	summary := fmt.Sprintf("New: %d\nResolved: %d\nPersisting: %d\n", len(d.New), len(d.Resolved), len(d.Persisting))
+	if o.noStyle {
+		fmt.Fprintf(w, "\n%s", summary)
+	} else {
+		fmt.Fprintln(w, summaryBoxStyle.Render(strings.TrimSuffix(summary, "\n")))
+	}
+	return nil
+}
+
+// outputDiffSection writes one list of a diff
+func (o *Outputter) outputDiffSection(w io.Writer, title string, findings []scanner.Finding, style lipgloss.Style) {
+	if len(findings) == 0 {
+		return
+	}
+
+	if o.noStyle {
+		fmt.Fprintf(w, "\n%s (%d)\n", title, len(findings))
+		fmt.Fprintln(w, strings.Repeat("-", 40))
+		for _, f := range findings {
+			fmt.Fprintf(w, "[%s] [%s] %s %s: %s\n", f.Severity, f.Detector, f.Location(), o.getLabelForFinding(f), f.Token)
+		}
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s\n", style.Render(fmt.Sprintf("%s (%d)", title, len(findings))))
+	fmt.Fprintln(w, strings.Repeat("─", 60))
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s %s %s\n", style.Render("▸"), f.Detector, pathStyle.Render(f.Location()))
+		fmt.Fprintf(w, "  %s %s\n", tokenStyle.Render(o.getLabelForFinding(f)+":"), f.Token)
+		fmt.Fprintf(w, "  %s %s\n", pathStyle.Render("Severity:"), severityLabel(f))
+	}
+}
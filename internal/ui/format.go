@@ -0,0 +1,29 @@
+package ui
+
+import "strings"
+
+// Format selects how scan results are written
+type Format string
+
+const (
+	FormatText   Format = "text"   // Styled or plain text, streamed as findings arrive
+	FormatJSON   Format = "json"   // A single JSON document
+	FormatSARIF  Format = "sarif"  // A SARIF 2.1.0 log for code scanning tools
+	FormatNDJSON Format = "ndjson" // One JSON event per line, streamed as findings arrive
+	FormatHTML   Format = "html"   // A self-contained HTML report
+)
+
+// ParseFormat parses a format name, case-insensitively
+func ParseFormat(s string) (Format, bool) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case FormatText, FormatJSON, FormatSARIF, FormatNDJSON, FormatHTML:
+		return f, true
+	}
+	return "", false
+}
+
+// Structured reports whether the format is a machine-readable document,
+// which is written once at the end instead of streamed
+func (f Format) Structured() bool {
+	return f == FormatJSON || f == FormatSARIF || f == FormatHTML
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/user/webhog/internal/crawler"
+	"github.com/user/webhog/internal/scanner"
+)
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+var reportTmpl = template.Must(template.New("report").Funcs(template.FuncMap{
+	"status": verificationStatus,
+	"upper":  strings.ToUpper,
+}).Parse(reportTemplate))
+
+// htmlReport is the data behind the HTML report template
+type htmlReport struct {
+	Version    string
+	Generated  string
+	Summary    Summary
+	Severities []scanner.Severity
+	Types      []scanner.DetectorType
+	Targets    []htmlTarget
+}
+
+// htmlTarget is one target's section of the HTML report
+type htmlTarget struct {
+	Target
+	Groups []htmlGroup
+	ByPage []htmlPage
+}
+
+// htmlPage is a crawled page with the findings in its HTML and scripts
+type htmlPage struct {
+	crawler.Page
+	Findings []scanner.Finding
+}
+
+// htmlGroup holds the findings of one type, most severe first
+type htmlGroup struct {
+	Type     scanner.DetectorType
+	Title    string
+	Findings []scanner.Finding
+}
+
+// findingTypes lists finding types in report order with their headings
+var findingTypes = []struct {
+	Type  scanner.DetectorType
+	Title string
+}{
+	{scanner.DetectorSecret, "Secrets"},
+	{scanner.DetectorConfig, "Configuration"},
+	{scanner.DetectorEndpoint, "Endpoints"},
+	{scanner.DetectorGeneric, "Generic"},
+}
+
+// outputHTML writes a self-contained HTML report with client-side filtering
+func (o *Outputter) outputHTML(w io.Writer, targets []Target) error {
+	report := htmlReport{
+		Version:   o.version,
+		Generated: time.Now().Format(time.RFC1123),
+		Summary:   Summarize(targets),
+		Severities: []scanner.Severity{
+			scanner.SeverityCritical,
+			scanner.SeverityHigh,
+			scanner.SeverityMedium,
+			scanner.SeverityLow,
+			scanner.SeverityInfo,
+		},
+	}
+	for _, ft := range findingTypes {
+		report.Types = append(report.Types, ft.Type)
+	}
+
+	for _, t := range targets {
+		byType := groupByType(t.Findings)
+		section := htmlTarget{Target: t}
+		for _, ft := range findingTypes {
+			if items := byType[ft.Type]; len(items) > 0 {
+				section.Groups = append(section.Groups, htmlGroup{
+					Type:     ft.Type,
+					Title:    ft.Title,
+					Findings: items,
+				})
+			}
+		}
+		if len(t.Pages()) > 1 {
+			section.ByPage = pageFindings(t)
+		}
+		report.Targets = append(report.Targets, section)
+	}
+
+	return reportTmpl.Execute(w, report)
+}
+
+// pageFindings breaks a crawl's findings down by page. A finding in a
+// script shared by several pages is listed under each of them.
+func pageFindings(t Target) []htmlPage {
+	var pages []htmlPage
+	for _, p := range t.Pages() {
+		paths := map[string]bool{p.URL: true}
+		for _, b := range p.Blobs {
+			paths[b.Path] = true
+		}
+
+		page := htmlPage{Page: p}
+		for _, f := range t.Findings {
+			if paths[f.Path] {
+				page.Findings = append(page.Findings, f)
+			}
+		}
+		pages = append(pages, page)
+	}
+	return pages
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/user/webhog/internal/crawler"
+	"github.com/user/webhog/internal/scanner"
+)
+
+// targetDoc is the JSON document written for a single target
+type targetDoc struct {
+	URL          string            `json:"url"`
+	Pages        []crawler.Page    `json:"pages"`
+	Technologies []string          `json:"technologies"`
+	Findings     []scanner.Finding `json:"findings"`
+	Suppressed   int               `json:"suppressed"`
+	Error        string            `json:"error"`
+}
+
+// LoadJSON reads results saved with --format json, accepting both the flat
+// single-target document and the multi-target one
+func LoadJSON(r io.Reader) ([]Target, error) {
+	var doc struct {
+		targetDoc
+		Targets []targetDoc `json:"targets"`
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decoding results: %w", err)
+	}
+
+	docs := doc.Targets
+	if docs == nil {
+		if doc.URL == "" {
+			return nil, fmt.Errorf("decoding results: not a webhog JSON report")
+		}
+		docs = []targetDoc{doc.targetDoc}
+	}
+
+	targets := make([]Target, len(docs))
+	for i, d := range docs {
+		targets[i] = Target{
+			URL:          d.URL,
+			Technologies: d.Technologies,
+			Findings:     d.Findings,
+			Suppressed:   d.Suppressed,
+			Error:        d.Error,
+		}
+		if d.Error == "" {
+			targets[i].Crawl = &crawler.Result{URL: d.URL, Pages: d.Pages}
+		}
+	}
+	return targets, nil
+}
+
+// LoadJSONFile reads saved results from a file
+func LoadJSONFile(path string) ([]Target, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	targets, err := LoadJSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return targets, nil
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/user/webhog/internal/crawler"
+	"github.com/user/webhog/internal/scanner"
+)
+
+// NDJSON event types; every line carries one of these in its "event" field
+const (
+	eventTarget  = "target"  // A target is about to be scanned
+	eventPage    = "page"    // A page was rendered and scanned
+	eventFinding = "finding" // A new deduplicated finding
+	eventResult  = "result"  // A target finished scanning
+	eventSummary = "summary" // All targets finished scanning
+)
+
+type targetEvent struct {
+	Event string `json:"event"`
+	URL   string `json:"url"`
+}
+
+type pageEvent struct {
+	Event string `json:"event"`
+	crawler.Page
+}
+
+type findingEvent struct {
+	Event  string `json:"event"`
+	Target string `json:"target,omitempty"`
+	scanner.Finding
+}
+
+type resultEvent struct {
+	Event        string   `json:"event"`
+	URL          string   `json:"url"`
+	FinalURL     string   `json:"final_url"`
+	Technologies []string `json:"technologies"`
+	Pages        int      `json:"pages"`
+	JSBlobs      int      `json:"js_blobs"`
+	Findings     int      `json:"findings"`
+	Suppressed   int      `json:"suppressed"`
+	Error        string   `json:"error,omitempty"`
+}
+
+type summaryEvent struct {
+	Event string `json:"event"`
+	Summary
+}
+
+// emit writes one event as a single JSON line. Pages are reported from the
+// crawler's goroutines, so writes are serialized to keep lines whole.
+func (o *Outputter) emit(w io.Writer, event interface{}) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	w.Write(append(data, '\n'))
+}
+
+// PrintPage reports a crawled page. Only NDJSON output shows page events.
+func (o *Outputter) PrintPage(w io.Writer, p crawler.Page) {
+	if o.format != FormatNDJSON {
+		return
+	}
+	o.emit(w, pageEvent{Event: eventPage, Page: p})
+}
+
+// PrintResult reports a finished target. Only NDJSON output shows result
+// events; text output prints its summaries at the end instead.
+func (o *Outputter) PrintResult(w io.Writer, t Target) {
+	if o.format != FormatNDJSON {
+		return
+	}
+	o.emit(w, resultEvent{
+		Event:        eventResult,
+		URL:          t.URL,
+		FinalURL:     t.FinalURL(),
+		Technologies: t.Technologies,
+		Pages:        len(t.Pages()),
+		JSBlobs:      t.JSBlobs(),
+		Findings:     len(t.Findings),
+		Suppressed:   t.Suppressed,
+		Error:        t.Error,
+	})
+}
+
+// outputNDJSON writes the complete event log for already finished targets,
+// in the same shape as a live stream
+func (o *Outputter) outputNDJSON(w io.Writer, targets []Target) error {
+	for _, t := range targets {
+		o.emit(w, targetEvent{Event: eventTarget, URL: t.URL})
+		for _, p := range t.Pages() {
+			o.emit(w, pageEvent{Event: eventPage, Page: p})
+		}
+		for _, f := range t.Findings {
+			o.emit(w, findingEvent{Event: eventFinding, Target: t.URL, Finding: f})
+		}
+		o.PrintResult(w, t)
+	}
+	o.emit(w, summaryEvent{Event: eventSummary, Summary: Summarize(targets)})
+	return nil
+}
@@ -4,48 +4,68 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/user/webhog/internal/renderer"
 	"github.com/user/webhog/internal/scanner"
 )
 
 // Outputter handles formatting and displaying results
 type Outputter struct {
-	noStyle    bool
-	jsonOutput bool
-	quiet      bool
-	seen       map[string]bool // Track seen findings to avoid duplicate output during streaming
+	noStyle   bool
+	format    Format
+	quiet     bool
+	version   string                      // Tool version reported in SARIF output
+	levels    map[string]scanner.Severity // Static severity of each detector, for SARIF rules
+	streaming bool                        // Whether the streaming header has been printed
+	target    string                      // Target currently being streamed
+	mu        sync.Mutex                  // Serializes NDJSON lines
 }
 
 // NewOutputter creates a new outputter
-func NewOutputter(noStyle, jsonOutput, quiet bool) *Outputter {
+func NewOutputter(noStyle bool, format Format, quiet bool) *Outputter {
 	return &Outputter{
-		noStyle:    noStyle,
-		jsonOutput: jsonOutput,
-		quiet:      quiet,
-		seen:       make(map[string]bool),
+		noStyle: noStyle,
+		format:  format,
+		quiet:   quiet,
+		levels:  detectorSeverities(scanner.GetDetectors()),
 	}
 }
 
+// SetVersion sets the tool version reported in machine-readable output
+func (o *Outputter) SetVersion(version string) {
+	o.version = version
+}
+
+// SetDetectors sets the detectors whose severities SARIF rules report, for
+// when custom rules are in use
+func (o *Outputter) SetDetectors(detectors []scanner.Detector) {
+	o.levels = detectorSeverities(detectors)
+}
+
 // StreamOutput reads findings from a channel and prints them progressively
 func (o *Outputter) StreamOutput(w io.Writer, findingsChan <-chan scanner.Finding) []scanner.Finding {
 	var allFindings []scanner.Finding
 
-	// Print header
-	if !o.quiet && !o.jsonOutput {
+	// Deduplicate within this target only; another target may load the
+	// same script and must report its findings too
+	seen := make(map[string]bool)
+
+	// Print header once, even when streaming several targets
+	if !o.quiet && o.format == FormatText && !o.streaming {
 		fmt.Fprintln(w, titleStyle.Render("Webhog Scan Results (Streaming)"))
 		fmt.Fprintln(w, strings.Repeat("─", 60))
 	}
+	o.streaming = true
 
 	for f := range findingsChan {
-		// Deduplicate for output stream
-		key := f.Detector + "|" + f.Path + "|" + f.Token
-		if o.seen[key] {
+		key := f.Path + "|" + f.Fingerprint
+		if seen[key] {
 			continue
 		}
-		o.seen[key] = true
+		seen[key] = true
 		allFindings = append(allFindings, f)
 
 		o.PrintFinding(w, f)
@@ -56,19 +76,26 @@ func (o *Outputter) StreamOutput(w io.Writer, findingsChan <-chan scanner.Findin
 
 // PrintFinding prints a single finding immediately
 func (o *Outputter) PrintFinding(w io.Writer, f scanner.Finding) {
-	if o.quiet {
+	if o.format == FormatNDJSON {
+		o.emit(w, findingEvent{Event: eventFinding, Target: o.target, Finding: f})
 		return
 	}
 
-	if o.jsonOutput {
-		// For JSON stream, we might want NDJSON or just suppress intermediate output?
-		// For now, let's just NOT print intermediate JSON to avoid broken JSON array.
+	if o.quiet || o.format.Structured() {
+		// Structured formats are written as one document at the end
 		return
 	}
 
 	if o.noStyle {
 		label := o.getLabelForFinding(f)
-		fmt.Fprintf(w, "[%s] %s:%d %s: %s\n", f.Detector, f.Path, f.LineNum, label, f.Token)
+		fmt.Fprintf(w, "[%s] [%s] %s %s: %s", f.Severity, f.Detector, f.Location(), label, f.Token)
+		if f.DecodeChain != "" {
+			fmt.Fprintf(w, " [decoded: %s]", f.DecodeChain)
+		}
+		if status := verificationStatus(f); status != "" {
+			fmt.Fprintf(w, " [%s]", status)
+		}
+		fmt.Fprintln(w)
 		return
 	}
 
@@ -88,33 +115,69 @@ func (o *Outputter) PrintFinding(w io.Writer, f scanner.Finding) {
 	label := o.getLabelForFinding(f)
 
 	fmt.Fprintf(w, "\n%s %s\n", style.Render("▸"), f.Detector)
-	fmt.Fprintf(w, "  %s %s:%d\n", pathStyle.Render("Location:"), f.Path, f.LineNum)
+	fmt.Fprintf(w, "  %s %s\n", pathStyle.Render("Location:"), f.Location())
+	fmt.Fprintf(w, "  %s %s\n", pathStyle.Render("Severity:"), severityLabel(f))
 	fmt.Fprintf(w, "  %s %s\n", tokenStyle.Render(label+":"), f.Token)
 	if f.Snippet != "" {
 		fmt.Fprintf(w, "  %s %s\n", snippetStyle.Render("Context:"), f.Snippet)
 	}
+	if f.DecodeChain != "" {
+		fmt.Fprintf(w, "  %s %s\n", snippetStyle.Render("Decoded:"), f.DecodeChain)
+	}
+	if status := verificationStatus(f); status != "" {
+		fmt.Fprintf(w, "  %s %s\n", snippetStyle.Render("Verified:"), status)
+	}
 }
 
-// Output writes the findings to the given writer
-func (o *Outputter) Output(w io.Writer, findings []scanner.Finding, result *renderer.RenderResult, technologies []string) error {
-	if o.jsonOutput {
-		return o.outputJSON(w, findings, result, technologies)
+// Output writes the results for all targets to the given writer
+func (o *Outputter) Output(w io.Writer, targets []Target) error {
+	targets = sortTargets(targets)
+
+	switch o.format {
+	case FormatJSON:
+		return o.outputJSON(w, targets)
+	case FormatSARIF:
+		return o.outputSARIF(w, targets)
+	case FormatNDJSON:
+		return o.outputNDJSON(w, targets)
+	case FormatHTML:
+		return o.outputHTML(w, targets)
 	}
 
 	if o.noStyle {
-		return o.outputPlain(w, findings, result, technologies)
+		return o.outputPlain(w, targets)
 	}
 
-	return o.outputStyled(w, findings, result, technologies)
+	return o.outputStyled(w, targets)
 }
 
-// outputJSON outputs findings as JSON
-func (o *Outputter) outputJSON(w io.Writer, findings []scanner.Finding, result *renderer.RenderResult, technologies []string) error {
-	output := map[string]interface{}{
-		"url":          result.URL,
-		"js_blobs":     len(result.JSBlobs),
-		"technologies": technologies,
-		"findings":     findings,
+// sortTargets returns a copy of the targets with each one's findings
+// ordered by severity
+func sortTargets(targets []Target) []Target {
+	sorted := make([]Target, len(targets))
+	for i, t := range targets {
+		t.Findings = append([]scanner.Finding(nil), t.Findings...)
+		scanner.SortFindings(t.Findings)
+		sorted[i] = t
+	}
+	return sorted
+}
+
+// outputJSON outputs findings as JSON. A single target keeps the flat
+// document; several targets are wrapped with a combined summary.
+func (o *Outputter) outputJSON(w io.Writer, targets []Target) error {
+	var output interface{}
+	if len(targets) == 1 {
+		output = targetJSON(targets[0])
+	} else {
+		docs := make([]map[string]interface{}, 0, len(targets))
+		for _, t := range targets {
+			docs = append(docs, targetJSON(t))
+		}
+		output = map[string]interface{}{
+			"targets": docs,
+			"summary": Summarize(targets),
+		}
 	}
 
 	encoder := json.NewEncoder(w)
@@ -122,25 +185,60 @@ func (o *Outputter) outputJSON(w io.Writer, findings []scanner.Finding, result *
 	return encoder.Encode(output)
 }
 
+// targetJSON builds the JSON document for a single target
+func targetJSON(t Target) map[string]interface{} {
+	output := map[string]interface{}{
+		"url":          t.FinalURL(),
+		"js_blobs":     t.JSBlobs(),
+		"pages":        t.Pages(),
+		"technologies": t.Technologies,
+		"findings":     t.Findings,
+		"suppressed":   t.Suppressed,
+	}
+	if t.Error != "" {
+		output["error"] = t.Error
+	}
+	return output
+}
+
 // outputPlain outputs findings in plain text
-func (o *Outputter) outputPlain(w io.Writer, findings []scanner.Finding, result *renderer.RenderResult, technologies []string) error {
+func (o *Outputter) outputPlain(w io.Writer, targets []Target) error {
+	for _, t := range targets {
+		o.outputPlainTarget(w, t)
+	}
+
+	if len(targets) > 1 && !o.quiet {
+		fmt.Fprintf(w, "\n%s\n", strings.Repeat("=", 40))
+		fmt.Fprint(w, o.buildCombinedSummary(targets))
+	}
+
+	return nil
+}
+
+// outputPlainTarget outputs the plain text results for a single target
+func (o *Outputter) outputPlainTarget(w io.Writer, t Target) {
+	findings := t.Findings
 	if !o.quiet {
-		fmt.Fprintf(w, "Scanned: %s\n", result.URL)
-		fmt.Fprintf(w, "Technologies: %s\n", strings.Join(technologies, ", "))
-		fmt.Fprintf(w, "JS Blobs: %d\n", len(result.JSBlobs))
+		fmt.Fprintf(w, "Scanned: %s\n", t.FinalURL())
+		if t.Error != "" {
+			fmt.Fprintf(w, "Error: %s\n\n", t.Error)
+			return
+		}
+		fmt.Fprintf(w, "Technologies: %s\n", strings.Join(t.Technologies, ", "))
+		if len(t.Pages()) > 1 {
+			fmt.Fprintf(w, "Pages: %d\n", len(t.Pages()))
+		}
+		fmt.Fprintf(w, "JS Blobs: %d\n", t.JSBlobs())
 		fmt.Fprintf(w, "Findings: %d\n\n", len(findings))
 	}
-	// ... (rest of plain output logic omitted for brevity, logic remains same)
 	if len(findings) == 0 {
-		if !o.quiet {
+		if !o.quiet && t.Error == "" {
 			fmt.Fprintln(w, "No secrets or interesting endpoints found.")
 		}
-		return nil
+		return
 	}
-	// ...
 	// Group findings by type
 	byType := groupByType(findings)
-	// ...
 	for _, fType := range []scanner.DetectorType{
 		scanner.DetectorSecret,
 		scanner.DetectorConfig,
@@ -157,33 +255,54 @@ func (o *Outputter) outputPlain(w io.Writer, findings []scanner.Finding, result
 
 		for _, f := range items {
 			label := o.getLabelForFinding(f)
-			fmt.Fprintf(w, "\n[%s] %s:%d\n", f.Detector, f.Path, f.LineNum)
+			fmt.Fprintf(w, "\n[%s] %s\n", f.Detector, f.Location())
 			fmt.Fprintf(w, "%s: %s\n", label, f.Token)
+			fmt.Fprintf(w, "Severity: %s\n", severityLabel(f))
 			if f.Snippet != "" {
 				fmt.Fprintf(w, "Context: %s\n", f.Snippet)
 			}
+			if f.DecodeChain != "" {
+				fmt.Fprintf(w, "Decoded: %s\n", f.DecodeChain)
+			}
+			if status := verificationStatus(f); status != "" {
+				fmt.Fprintf(w, "Verified: %s\n", status)
+			}
 		}
 	}
-
-	return nil
+	fmt.Fprintln(w)
 }
 
 // outputStyled outputs findings with styled formatting
-func (o *Outputter) outputStyled(w io.Writer, findings []scanner.Finding, result *renderer.RenderResult, technologies []string) error {
+func (o *Outputter) outputStyled(w io.Writer, targets []Target) error {
 	// Title
 	fmt.Fprintln(w, titleStyle.Render("Webhog Scan Results"))
 
+	for _, t := range targets {
+		o.outputStyledTarget(w, t)
+	}
+
+	if len(targets) > 1 && !o.quiet {
+		fmt.Fprintln(w, summaryBoxStyle.Render(o.buildCombinedSummary(targets)))
+	}
+
+	return nil
+}
+
+// outputStyledTarget outputs the styled results for a single target
+func (o *Outputter) outputStyledTarget(w io.Writer, t Target) {
+	findings := t.Findings
+
 	// Summary
 	if !o.quiet {
-		summary := o.buildSummary(result, findings, technologies)
+		summary := o.buildSummary(t)
 		fmt.Fprintln(w, summaryBoxStyle.Render(summary))
 	}
 
 	if len(findings) == 0 {
-		if !o.quiet {
+		if !o.quiet && t.Error == "" {
 			fmt.Fprintln(w, "No secrets or interesting endpoints found.")
 		}
-		return nil
+		return
 	}
 
 	// Group findings by type
@@ -194,33 +313,103 @@ func (o *Outputter) outputStyled(w io.Writer, findings []scanner.Finding, result
 	o.outputTypeSection(w, "CONFIGURATION", scanner.DetectorConfig, byType[scanner.DetectorConfig], configStyle)
 	o.outputTypeSection(w, "ENDPOINTS", scanner.DetectorEndpoint, byType[scanner.DetectorEndpoint], endpointStyle)
 	o.outputTypeSection(w, "GENERIC", scanner.DetectorGeneric, byType[scanner.DetectorGeneric], genericStyle)
-
-	return nil
 }
 
-// PrintSummary prints just the summary box
-func (o *Outputter) PrintSummary(w io.Writer, findings []scanner.Finding, result *renderer.RenderResult, technologies []string) {
-	summary := o.buildSummary(result, findings, technologies)
+// PrintTarget prints a heading announcing the target about to be streamed
+func (o *Outputter) PrintTarget(w io.Writer, targetURL string) {
+	o.target = targetURL
+	if o.format == FormatNDJSON {
+		o.emit(w, targetEvent{Event: eventTarget, URL: targetURL})
+		return
+	}
+
+	if o.quiet || o.format.Structured() {
+		return
+	}
 
 	if o.noStyle {
-		fmt.Fprintln(w, summary)
-	} else {
-		fmt.Fprintln(w, summaryBoxStyle.Render(summary))
+		fmt.Fprintf(w, "\n== %s ==\n", targetURL)
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s %s\n", headerStyle.Render("Target:"), targetURL)
+}
+
+// PrintSummary prints just the summary box(es): one per target, plus a
+// combined summary when several targets were scanned. NDJSON output gets a
+// single summary event instead.
+func (o *Outputter) PrintSummary(w io.Writer, targets []Target) {
+	if o.format == FormatNDJSON {
+		o.emit(w, summaryEvent{Event: eventSummary, Summary: Summarize(targets)})
+		return
+	}
+
+	var summaries []string
+	for _, t := range targets {
+		summaries = append(summaries, o.buildSummary(t))
+	}
+	if len(targets) > 1 {
+		summaries = append(summaries, o.buildCombinedSummary(targets))
+	}
+
+	for _, summary := range summaries {
+		if o.noStyle {
+			fmt.Fprintln(w, summary)
+		} else {
+			fmt.Fprintln(w, summaryBoxStyle.Render(summary))
+		}
 	}
 }
 
-// buildSummary creates a summary string
-func (o *Outputter) buildSummary(result *renderer.RenderResult, findings []scanner.Finding, technologies []string) string {
+// buildSummary creates a summary string for a single target
+func (o *Outputter) buildSummary(t Target) string {
 	var b strings.Builder
 
-	b.WriteString(fmt.Sprintf("URL: %s\n", result.URL))
-	if len(technologies) > 0 {
-		b.WriteString(fmt.Sprintf("Tech: %s\n", strings.Join(technologies, ", ")))
+	b.WriteString(fmt.Sprintf("URL: %s\n", t.FinalURL()))
+	if t.Error != "" {
+		b.WriteString(fmt.Sprintf("Error: %s\n", t.Error))
+		return b.String()
 	}
-	b.WriteString(fmt.Sprintf("JS Blobs: %d\n", len(result.JSBlobs)))
-	b.WriteString(fmt.Sprintf("Total Findings: %d\n\n", len(findings)))
+	if len(t.Technologies) > 0 {
+		b.WriteString(fmt.Sprintf("Tech: %s\n", strings.Join(t.Technologies, ", ")))
+	}
+	if len(t.Pages()) > 1 {
+		b.WriteString(fmt.Sprintf("Pages: %d\n", len(t.Pages())))
+	}
+	b.WriteString(fmt.Sprintf("JS Blobs: %d\n", t.JSBlobs()))
+	b.WriteString(fmt.Sprintf("Total Findings: %d\n", len(t.Findings)))
+	if t.Suppressed > 0 {
+		b.WriteString(fmt.Sprintf("Suppressed: %d\n", t.Suppressed))
+	}
+	b.WriteString("\n")
 
-	// Count by type
+	writeTypeCounts(&b, t.Findings)
+
+	return b.String()
+}
+
+// buildCombinedSummary creates a summary string across all targets
+func (o *Outputter) buildCombinedSummary(targets []Target) string {
+	var b strings.Builder
+	summary := Summarize(targets)
+
+	b.WriteString("Combined Summary\n")
+	b.WriteString(fmt.Sprintf("Targets: %d (%d failed)\n", summary.Targets, summary.Failed))
+	b.WriteString(fmt.Sprintf("Pages: %d\n", summary.Pages))
+	b.WriteString(fmt.Sprintf("JS Blobs: %d\n", summary.JSBlobs))
+	b.WriteString(fmt.Sprintf("Total Findings: %d\n", summary.Findings))
+	if summary.Suppressed > 0 {
+		b.WriteString(fmt.Sprintf("Suppressed: %d\n", summary.Suppressed))
+	}
+	b.WriteString("\n")
+
+	writeTypeCounts(&b, allFindings(targets))
+
+	return b.String()
+}
+
+// writeTypeCounts writes the per-type finding counts of a summary
+func writeTypeCounts(b *strings.Builder, findings []scanner.Finding) {
 	byType := groupByType(findings)
 	b.WriteString("By Type:\n")
 	b.WriteString(fmt.Sprintf("  Secrets:       %d\n", len(byType[scanner.DetectorSecret])))
@@ -228,7 +417,21 @@ func (o *Outputter) buildSummary(result *renderer.RenderResult, findings []scann
 	b.WriteString(fmt.Sprintf("  Endpoints:     %d\n", len(byType[scanner.DetectorEndpoint])))
 	b.WriteString(fmt.Sprintf("  Generic:       %d\n", len(byType[scanner.DetectorGeneric])))
 
-	return b.String()
+	bySeverity := make(map[scanner.Severity]int)
+	for _, f := range findings {
+		bySeverity[f.Severity]++
+	}
+	b.WriteString("\nBy Severity:\n")
+	for _, sev := range []scanner.Severity{
+		scanner.SeverityCritical,
+		scanner.SeverityHigh,
+		scanner.SeverityMedium,
+		scanner.SeverityLow,
+		scanner.SeverityInfo,
+	} {
+		name := string(sev)
+		b.WriteString(fmt.Sprintf("  %-14s %d\n", strings.ToUpper(name[:1])+name[1:]+":", bySeverity[sev]))
+	}
 }
 
 // outputTypeSection outputs a section for a specific finding type
@@ -243,11 +446,18 @@ func (o *Outputter) outputTypeSection(w io.Writer, title string, fType scanner.D
 	for _, f := range findings {
 		label := o.getLabelForFinding(f)
 		fmt.Fprintf(w, "\n%s %s\n", style.Render("▸"), f.Detector)
-		fmt.Fprintf(w, "  %s %s:%d\n", pathStyle.Render("Location:"), f.Path, f.LineNum)
+		fmt.Fprintf(w, "  %s %s\n", pathStyle.Render("Location:"), f.Location())
+		fmt.Fprintf(w, "  %s %s\n", pathStyle.Render("Severity:"), severityLabel(f))
 		fmt.Fprintf(w, "  %s %s\n", tokenStyle.Render(label+":"), f.Token)
 		if f.Snippet != "" {
 			fmt.Fprintf(w, "  %s %s\n", snippetStyle.Render("Context:"), f.Snippet)
 		}
+		if f.DecodeChain != "" {
+			fmt.Fprintf(w, "  %s %s\n", snippetStyle.Render("Decoded:"), f.DecodeChain)
+		}
+		if status := verificationStatus(f); status != "" {
+			fmt.Fprintf(w, "  %s %s\n", snippetStyle.Render("Verified:"), status)
+		}
 	}
 
 	fmt.Fprintln(w)
@@ -261,6 +471,37 @@ func (o *Outputter) getLabelForFinding(f scanner.Finding) string {
 	return "Secret"
 }
 
+// severityLabel describes the severity and confidence of a finding
+func severityLabel(f scanner.Finding) string {
+	return fmt.Sprintf("%s (confidence: %s)", f.Severity, f.Confidence)
+}
+
+// verificationStatus describes the verification outcome of a finding, or
+// returns "" if it was not checked
+func verificationStatus(f scanner.Finding) string {
+	switch {
+	case f.Verified != nil && *f.Verified:
+		keys := make([]string, 0, len(f.Metadata))
+		for k := range f.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		details := make([]string, len(keys))
+		for i, k := range keys {
+			details[i] = k + "=" + f.Metadata[k]
+		}
+		if len(details) == 0 {
+			return "live"
+		}
+		return "live (" + strings.Join(details, ", ") + ")"
+	case f.Verified != nil:
+		return "not live"
+	case f.VerificationError != "":
+		return "unknown: " + f.VerificationError
+	}
+	return ""
+}
+
 // groupByType groups findings by their detector type
 func groupByType(findings []scanner.Finding) map[scanner.DetectorType][]scanner.Finding {
 	result := make(map[scanner.DetectorType][]scanner.Finding)
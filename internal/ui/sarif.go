@@ -0,0 +1,313 @@
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/user/webhog/internal/scanner"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "webhog"
+	toolURI      = "https://github.com/user/webhog"
+)
+
+// sarifLog is the root of a SARIF 2.1.0 document
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+	ColumnKind  string            `json:"columnKind"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration     `json:"defaultConfiguration"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID     string                 `json:"ruleId"`
+	RuleIndex  int                    `json:"ruleIndex"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+	ContextRegion    *sarifRegion          `json:"contextRegion,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int           `json:"startLine"`
+	StartColumn int           `json:"startColumn,omitempty"`
+	EndLine     int           `json:"endLine,omitempty"`
+	EndColumn   int           `json:"endColumn,omitempty"`
+	ByteOffset  *int          `json:"byteOffset,omitempty"`
+	ByteLength  int           `json:"byteLength,omitempty"`
+	Snippet     *sarifMessage `json:"snippet,omitempty"`
+}
+
+// outputSARIF writes all targets as a single SARIF run, with one rule per
+// detector that produced a finding
+func (o *Outputter) outputSARIF(w io.Writer, targets []Target) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           toolName,
+			Version:        o.version,
+			InformationURI: toolURI,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+		// Finding columns count characters, not UTF-16 code units
+		ColumnKind: "unicodeCodePoints",
+	}
+
+	invocation := sarifInvocation{ExecutionSuccessful: true}
+	ruleIndex := make(map[string]int)
+
+	for _, t := range targets {
+		if t.Error != "" {
+			invocation.ExecutionSuccessful = false
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+				Level:     "error",
+				Message:   sarifMessage{Text: t.Error},
+				Locations: []sarifLocation{artifactLocation(t.URL, nil)},
+			})
+		}
+
+		for _, f := range t.Findings {
+			idx, ok := ruleIndex[f.Detector]
+			if !ok {
+				idx = len(run.Tool.Driver.Rules)
+				ruleIndex[f.Detector] = idx
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRuleFor(f, o.ruleSeverity(f)))
+			}
+
+			run.Results = append(run.Results, sarifResult{
+				RuleID:     run.Tool.Driver.Rules[idx].ID,
+				RuleIndex:  idx,
+				Level:      sarifLevel(f.Severity),
+				Message:    sarifMessage{Text: f.Detector + " found: " + f.Token},
+				Locations:  []sarifLocation{findingLocation(f)},
+				Properties: sarifProperties(f),
+			})
+		}
+	}
+	run.Invocations = []sarifInvocation{invocation}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifRuleFor describes the detector behind a finding as a SARIF rule,
+// with the detector's own severity rather than the finding's scored one
+func sarifRuleFor(f scanner.Finding, sev scanner.Severity) sarifRule {
+	return sarifRule{
+		ID:                   ruleID(f.Detector),
+		Name:                 f.Detector,
+		ShortDescription:     sarifMessage{Text: f.Detector},
+		DefaultConfiguration: sarifConfiguration{Level: sarifLevel(sev)},
+		Properties: map[string]interface{}{
+			"tags":              []string{"security", string(f.Type)},
+			"security-severity": securitySeverity(sev),
+		},
+	}
+}
+
+// ruleSeverity returns the static severity of the detector behind a
+// finding, falling back to the default for its type for detectors not
+// known here, such as the entropy check
+func (o *Outputter) ruleSeverity(f scanner.Finding) scanner.Severity {
+	if sev, ok := o.levels[f.Detector]; ok {
+		return sev
+	}
+	return scanner.DefaultSeverity(f.Type)
+}
+
+// detectorSeverities maps each detector's name to its static severity
+func detectorSeverities(detectors []scanner.Detector) map[string]scanner.Severity {
+	levels := make(map[string]scanner.Severity, len(detectors))
+	for _, d := range detectors {
+		sev := d.Severity
+		if sev == "" {
+			sev = scanner.DefaultSeverity(d.Type)
+		}
+		levels[d.Name] = sev
+	}
+	return levels
+}
+
+// artifactLocation builds a location for a path and optional region
+func artifactLocation(path string, region *sarifRegion) sarifLocation {
+	return sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: path},
+		Region:           region,
+	}}
+}
+
+// findingLocation locates a finding in its file, with the line around it
+// as the context region
+func findingLocation(f scanner.Finding) sarifLocation {
+	loc := artifactLocation(f.Path, findingRegion(f))
+	if loc.PhysicalLocation.Region != nil && f.Snippet != "" {
+		loc.PhysicalLocation.ContextRegion = &sarifRegion{
+			StartLine: f.LineNum,
+			EndLine:   f.EndLine,
+			Snippet:   &sarifMessage{Text: f.Snippet},
+		}
+	}
+	return loc
+}
+
+// findingRegion locates a finding in its file: by line and column, and by
+// byte range when the offsets are known. The snippet is the token, when it
+// is the text of the region as found in the source rather than decoded or
+// rewritten from it.
+func findingRegion(f scanner.Finding) *sarifRegion {
+	if f.LineNum <= 0 {
+		return nil
+	}
+	region := &sarifRegion{StartLine: f.LineNum}
+	if f.Column > 0 {
+		region.StartColumn = f.Column
+		region.EndLine = f.EndLine
+		region.EndColumn = f.EndColumn
+	}
+	if f.End > f.Start {
+		start := f.Start
+		region.ByteOffset = &start
+		region.ByteLength = f.End - f.Start
+	}
+	if f.Token != "" && f.DecodeChain == "" && strings.Contains(f.Snippet, f.Token) {
+		region.Snippet = &sarifMessage{Text: f.Token}
+	}
+	return region
+}
+
+// sarifProperties carries the webhog-specific fields of a finding
+func sarifProperties(f scanner.Finding) map[string]interface{} {
+	props := map[string]interface{}{
+		"type":       f.Type,
+		"severity":   f.Severity,
+		"confidence": f.Confidence,
+		"token":      f.Token,
+	}
+	if f.Verified != nil {
+		props["verified"] = *f.Verified
+	}
+	if f.VerificationError != "" {
+		props["verification_error"] = f.VerificationError
+	}
+	if len(f.Metadata) > 0 {
+		props["metadata"] = f.Metadata
+	}
+	if f.DecodeChain != "" {
+		props["decode_chain"] = f.DecodeChain
+	}
+	if f.FormattedLine > 0 {
+		props["formatted_line"] = f.FormattedLine
+	}
+	return props
+}
+
+// ruleID turns a detector name into a stable rule ID, e.g.
+// "AWS Access Key ID" becomes "aws-access-key-id"
+func ruleID(detector string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(detector) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// sarifLevel maps a severity to a SARIF result level
+func sarifLevel(sev scanner.Severity) string {
+	switch sev {
+	case scanner.SeverityCritical, scanner.SeverityHigh:
+		return "error"
+	case scanner.SeverityMedium:
+		return "warning"
+	}
+	return "note"
+}
+
+// securitySeverity maps a severity to the CVSS-like score GitHub code
+// scanning uses to rank security results
+func securitySeverity(sev scanner.Severity) string {
+	switch sev {
+	case scanner.SeverityCritical:
+		return "9.5"
+	case scanner.SeverityHigh:
+		return "8.0"
+	case scanner.SeverityMedium:
+		return "5.5"
+	case scanner.SeverityLow:
+		return "2.0"
+	}
+	return "0.0"
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"github.com/user/webhog/internal/crawler"
+	"github.com/user/webhog/internal/scanner"
+)
+
+// Target holds the results of scanning a single start URL
+type Target struct {
+	URL          string            // The URL as given by the user
+	Crawl        *crawler.Result   // Crawl result, nil if the target could not be rendered
+	Technologies []string          // Technologies detected on the start page
+	Findings     []scanner.Finding // Deduplicated findings across all crawled pages
+	Suppressed   int               // Findings hidden by the ignore file
+	Error        string            // Why the target failed, if it did
+}
+
+// FinalURL returns the final URL of the start page, falling back to the
+// requested URL when rendering failed
+func (t Target) FinalURL() string {
+	if t.Crawl != nil {
+		return t.Crawl.URL
+	}
+	return t.URL
+}
+
+// Pages returns the crawled pages, if any
+func (t Target) Pages() []crawler.Page {
+	if t.Crawl == nil {
+		return nil
+	}
+	return t.Crawl.Pages
+}
+
+// JSBlobs returns the number of JS blobs scanned for this target
+func (t Target) JSBlobs() int {
+	if t.Crawl == nil {
+		return 0
+	}
+	return t.Crawl.JSBlobs()
+}
+
+// Summary aggregates counts across all scanned targets
+type Summary struct {
+	Targets    int                          `json:"targets"`
+	Failed     int                          `json:"failed"`
+	Pages      int                          `json:"pages"`
+	JSBlobs    int                          `json:"js_blobs"`
+	Findings   int                          `json:"findings"`
+	Suppressed int                          `json:"suppressed"`
+	ByType     map[scanner.DetectorType]int `json:"by_type"`
+	BySeverity map[scanner.Severity]int     `json:"by_severity"`
+}
+
+// Summarize builds the combined summary for a set of targets
+func Summarize(targets []Target) Summary {
+	s := Summary{
+		Targets:    len(targets),
+		ByType:     make(map[scanner.DetectorType]int),
+		BySeverity: make(map[scanner.Severity]int),
+	}
+
+	for _, t := range targets {
+		if t.Error != "" {
+			s.Failed++
+		}
+		s.Pages += len(t.Pages())
+		s.JSBlobs += t.JSBlobs()
+		s.Findings += len(t.Findings)
+		s.Suppressed += t.Suppressed
+		for _, f := range t.Findings {
+			s.ByType[f.Type]++
+			s.BySeverity[f.Severity]++
+		}
+	}
+
+	return s
+}
+
+// allFindings flattens the findings of every target
+func allFindings(targets []Target) []scanner.Finding {
+	var findings []scanner.Finding
+	for _, t := range targets {
+		findings = append(findings, t.Findings...)
+	}
+	return findings
+}
@@ -0,0 +1,102 @@
+package verify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	awsRegion  = "us-east-1"
+	awsService = "sts"
+	awsBody    = "Action=GetCallerIdentity&Version=2011-06-15"
+)
+
+// checkAWS calls STS GetCallerIdentity signed with the key pair
+func checkAWS(ctx context.Context, client *http.Client, baseURL, keyID, secret string) Result {
+	endpoint := baseURL + "/"
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return Result{Err: fmt.Errorf("invalid endpoint: %w", err)}
+	}
+
+	header := signV4(u.Host, keyID, secret, time.Now().UTC())
+	status, body, err := request(ctx, client, http.MethodPost, endpoint, strings.NewReader(awsBody), header)
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	switch status {
+	case http.StatusOK:
+		var identity struct {
+			Result struct {
+				Arn     string `xml:"Arn"`
+				Account string `xml:"Account"`
+				UserID  string `xml:"UserId"`
+			} `xml:"GetCallerIdentityResult"`
+		}
+		result := Result{Verified: true}
+		if xml.Unmarshal(body, &identity) == nil {
+			r := identity.Result
+			result.Metadata = metadata("arn", r.Arn, "account", r.Account, "user_id", r.UserID)
+		}
+		return result
+	case http.StatusForbidden:
+		// InvalidClientTokenId or SignatureDoesNotMatch
+		return Result{Verified: false}
+	}
+	return statusResult(status)
+}
+
+// signV4 builds the headers for an AWS Signature Version 4 signed request
+// carrying awsBody to the root path of host
+func signV4(host, keyID, secret string, now time.Time) http.Header {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(awsBody)
+	contentType := "application/x-www-form-urlencoded; charset=utf-8"
+
+	canonicalHeaders := "content-type:" + contentType + "\n" +
+		"host:" + host + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	signedHeaders := "content-type;host;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		http.MethodPost, "/", "", canonicalHeaders, signedHeaders, payloadHash,
+	}, "\n")
+
+	scope := date + "/" + awsRegion + "/" + awsService + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, awsRegion)
+	key = hmacSHA256(key, awsService)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	return http.Header{
+		"Content-Type": {contentType},
+		"X-Amz-Date":   {amzDate},
+		"Authorization": {fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+			keyID, scope, signedHeaders, signature)},
+	}
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,222 @@
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxResponseBody caps how much of a provider response is read
+const maxResponseBody = 1 << 20
+
+// request sends a verification request and returns the status and body
+func request(ctx context.Context, client *http.Client, method, url string, body io.Reader, header http.Header) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("building request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("User-Agent", "webhog")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return 0, nil, fmt.Errorf("reading response: %w", err)
+	}
+	return resp.StatusCode, data, nil
+}
+
+// statusResult maps an HTTP status to a result for providers that answer
+// 200 for valid credentials and 401 or 403 for invalid ones
+func statusResult(status int) Result {
+	switch status {
+	case http.StatusOK:
+		return Result{Verified: true}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return Result{Verified: false}
+	default:
+		return Result{Err: fmt.Errorf("unexpected HTTP %d", status)}
+	}
+}
+
+// checkGitHub calls GET /user with the token
+func checkGitHub(ctx context.Context, client *http.Client, baseURL, token string) Result {
+	status, body, err := request(ctx, client, http.MethodGet, baseURL+"/user", nil, http.Header{
+		"Authorization": {"token " + token},
+		"Accept":        {"application/vnd.github+json"},
+	})
+	if err != nil {
+		return Result{Err: err}
+	}
+	if status == http.StatusForbidden {
+		// Rate limited or blocked, which says nothing about the token
+		return Result{Err: fmt.Errorf("unexpected HTTP %d", status)}
+	}
+
+	result := statusResult(status)
+	if result.Verified {
+		var user struct {
+			Login string `json:"login"`
+			Name  string `json:"name"`
+			Type  string `json:"type"`
+		}
+		if json.Unmarshal(body, &user) == nil {
+			result.Metadata = metadata("login", user.Login, "name", user.Name, "type", user.Type)
+		}
+	}
+	return result
+}
+
+// checkStripe calls GET /v1/balance with the key. Restricted keys without
+// balance access get a 403 but are still live.
+func checkStripe(ctx context.Context, client *http.Client, baseURL, token string) Result {
+	req := http.Header{"Authorization": {basicAuth(token, "")}}
+	status, body, err := request(ctx, client, http.MethodGet, baseURL+"/v1/balance", nil, req)
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	switch status {
+	case http.StatusOK:
+		var balance struct {
+			LiveMode bool `json:"livemode"`
+		}
+		result := Result{Verified: true}
+		if json.Unmarshal(body, &balance) == nil {
+			result.Metadata = metadata("livemode", fmt.Sprint(balance.LiveMode))
+		}
+		return result
+	case http.StatusForbidden:
+		return Result{Verified: true, Metadata: metadata("access", "restricted")}
+	}
+	return statusResult(status)
+}
+
+// checkSlack calls auth.test, which answers 200 with an ok flag
+func checkSlack(ctx context.Context, client *http.Client, baseURL, token string) Result {
+	status, body, err := request(ctx, client, http.MethodPost, baseURL+"/auth.test", nil, http.Header{
+		"Authorization": {"Bearer " + token},
+	})
+	if err != nil {
+		return Result{Err: err}
+	}
+	if status != http.StatusOK {
+		return statusResult(status)
+	}
+
+	var auth struct {
+		OK     bool   `json:"ok"`
+		Error  string `json:"error"`
+		Team   string `json:"team"`
+		User   string `json:"user"`
+		URL    string `json:"url"`
+		TeamID string `json:"team_id"`
+	}
+	if err := json.Unmarshal(body, &auth); err != nil {
+		return Result{Err: fmt.Errorf("decoding response: %w", err)}
+	}
+
+	switch {
+	case auth.OK:
+		return Result{Verified: true, Metadata: metadata("team", auth.Team, "team_id", auth.TeamID, "user", auth.User, "url", auth.URL)}
+	case auth.Error == "invalid_auth" || auth.Error == "not_authed" ||
+		auth.Error == "account_inactive" || auth.Error == "token_revoked" || auth.Error == "token_expired":
+		return Result{Verified: false}
+	}
+	return Result{Err: fmt.Errorf("slack error: %s", auth.Error)}
+}
+
+// checkSendGrid calls GET /v3/scopes and records how many scopes the key has
+func checkSendGrid(ctx context.Context, client *http.Client, baseURL, token string) Result {
+	status, body, err := request(ctx, client, http.MethodGet, baseURL+"/v3/scopes", nil, http.Header{
+		"Authorization": {"Bearer " + token},
+	})
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	result := statusResult(status)
+	if result.Verified {
+		var scopes struct {
+			Scopes []string `json:"scopes"`
+		}
+		if json.Unmarshal(body, &scopes) == nil {
+			result.Metadata = metadata("scopes", strings.Join(scopes.Scopes, ","))
+		}
+	}
+	return result
+}
+
+// checkMailgun calls GET /v3/domains with the key as the api user's password
+func checkMailgun(ctx context.Context, client *http.Client, baseURL, token string) Result {
+	status, body, err := request(ctx, client, http.MethodGet, baseURL+"/v3/domains", nil, http.Header{
+		"Authorization": {basicAuth("api", token)},
+	})
+	if err != nil {
+		return Result{Err: err}
+	}
+
+	result := statusResult(status)
+	if result.Verified {
+		var domains struct {
+			TotalCount int `json:"total_count"`
+		}
+		if json.Unmarshal(body, &domains) == nil {
+			result.Metadata = metadata("domains", fmt.Sprint(domains.TotalCount))
+		}
+	}
+	return result
+}
+
+// checkTelegram calls getMe for the bot token
+func checkTelegram(ctx context.Context, client *http.Client, baseURL, token string) Result {
+	status, body, err := request(ctx, client, http.MethodGet, baseURL+"/bot"+token+"/getMe", nil, nil)
+	if err != nil {
+		return Result{Err: err}
+	}
+	if status == http.StatusNotFound {
+		return Result{Verified: false}
+	}
+
+	result := statusResult(status)
+	if result.Verified {
+		var me struct {
+			Result struct {
+				ID       int64  `json:"id"`
+				Username string `json:"username"`
+			} `json:"result"`
+		}
+		if json.Unmarshal(body, &me) == nil {
+			result.Metadata = metadata("username", me.Result.Username, "id", fmt.Sprint(me.Result.ID))
+		}
+	}
+	return result
+}
+
+// basicAuth builds a Basic Authorization header value
+func basicAuth(user, password string) string {
+	req := &http.Request{Header: http.Header{}}
+	req.SetBasicAuth(user, password)
+	return req.Header.Get("Authorization")
+}
+
+// metadata builds a map from key/value pairs, skipping empty values
+func metadata(pairs ...string) map[string]string {
+	m := make(map[string]string)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			m[pairs[i]] = pairs[i+1]
+		}
+	}
+	return m
+}
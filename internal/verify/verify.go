@@ -0,0 +1,198 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/user/webhog/internal/scanner"
+)
+
+// DefaultEndpoints are the provider API base URLs used for verification.
+// Each can be overridden, e.g. to point at a local stand-in for testing.
+var DefaultEndpoints = map[string]string{
+	"aws":      "https://sts.amazonaws.com",
+	"github":   "https://api.github.com",
+	"mailgun":  "https://api.mailgun.net",
+	"sendgrid": "https://api.sendgrid.com",
+	"slack":    "https://slack.com/api",
+	"stripe":   "https://api.stripe.com",
+	"telegram": "https://api.telegram.org",
+}
+
+// Result is the outcome of checking a single credential
+type Result struct {
+	Verified bool              // The provider accepted the credential
+	Err      error             // Verification could not be completed
+	Metadata map[string]string // Account details returned by the provider
+}
+
+// checker verifies a single token against a provider's base URL
+type checker func(ctx context.Context, client *http.Client, baseURL, token string) Result
+
+// provider ties detectors to the API that can verify their tokens
+type provider struct {
+	name  string
+	check checker
+}
+
+// providers maps detector names to their verification provider. AWS keys
+// are handled separately because they need a key ID and secret pair.
+var providers = map[string]provider{
+	"GitHub Personal Access Token": {"github", checkGitHub},
+	"GitHub OAuth Token":           {"github", checkGitHub},
+	"Stripe API Key":               {"stripe", checkStripe},
+	"Stripe Restricted API Key":    {"stripe", checkStripe},
+	"Slack Token":                  {"slack", checkSlack},
+	"SendGrid API Key":             {"sendgrid", checkSendGrid},
+	"MailGun API Key":              {"mailgun", checkMailgun},
+	"Telegram Bot API Key":         {"telegram", checkTelegram},
+}
+
+// Verifier checks findings against live provider APIs. It implements
+// scanner.Verifier and caches results so each credential is only sent to
+// its provider once per run.
+type Verifier struct {
+	client    *http.Client
+	endpoints map[string]string
+
+	mu    sync.Mutex
+	cache map[string]*cacheEntry
+}
+
+// cacheEntry lets concurrent lookups of the same credential share one request
+type cacheEntry struct {
+	once   sync.Once
+	result Result
+}
+
+// New creates a verifier. Entries in endpoints override DefaultEndpoints;
+// unknown provider names are rejected.
+func New(endpoints map[string]string, timeout time.Duration) (*Verifier, error) {
+	merged := make(map[string]string, len(DefaultEndpoints))
+	for name, u := range DefaultEndpoints {
+		merged[name] = u
+	}
+	for name, u := range endpoints {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := DefaultEndpoints[name]; !ok {
+			return nil, fmt.Errorf("unknown verification provider %q (want one of %s)", name, strings.Join(Providers(), ", "))
+		}
+		merged[name] = strings.TrimSuffix(u, "/")
+	}
+
+	return &Verifier{
+		client:    &http.Client{Timeout: timeout},
+		endpoints: merged,
+		cache:     make(map[string]*cacheEntry),
+	}, nil
+}
+
+// Providers returns the names of all supported verification providers
+func Providers() []string {
+	names := make([]string, 0, len(DefaultEndpoints))
+	for name := range DefaultEndpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Verify checks every verifiable secret among the findings of one blob and
+// records the outcome on each finding
+func (v *Verifier) Verify(ctx context.Context, findings []scanner.Finding) {
+	v.verifyAWS(ctx, findings)
+
+	for i := range findings {
+		p, ok := providers[findings[i].Detector]
+		if !ok {
+			continue
+		}
+
+		token := findings[i].Token
+		result := v.cached(p.name+"|"+token, func() Result {
+			return p.check(ctx, v.client, v.endpoints[p.name], token)
+		})
+		apply(&findings[i], result)
+	}
+}
+
+// verifyAWS pairs access key IDs with secret keys found in the same blob
+// and checks each pair with STS GetCallerIdentity
+func (v *Verifier) verifyAWS(ctx context.Context, findings []scanner.Finding) {
+	var keyIDs, secrets []int
+	for i, f := range findings {
+		switch f.Detector {
+		case "AWS Access Key ID":
+			keyIDs = append(keyIDs, i)
+		case "AWS Secret Key":
+			secrets = append(secrets, i)
+		}
+	}
+
+	for _, k := range keyIDs {
+		keyID := findings[k].Token
+		if len(secrets) == 0 {
+			findings[k].VerificationError = "no AWS secret key found alongside the access key ID"
+			continue
+		}
+
+		for _, s := range secrets {
+			secret := findings[s].Token
+			result := v.cached("aws|"+keyID+"|"+secret, func() Result {
+				return checkAWS(ctx, v.client, v.endpoints["aws"], keyID, secret)
+			})
+
+			apply(&findings[k], result)
+			apply(&findings[s], result)
+			if result.Verified {
+				break
+			}
+		}
+	}
+}
+
+// cached runs check once per key and returns the shared result
+func (v *Verifier) cached(key string, check func() Result) Result {
+	v.mu.Lock()
+	entry, ok := v.cache[key]
+	if !ok {
+		entry = &cacheEntry{}
+		v.cache[key] = entry
+	}
+	v.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.result = check()
+	})
+	return entry.result
+}
+
+// apply records a verification result on a finding. A positive result is
+// never overwritten by a later negative one.
+func apply(f *scanner.Finding, result Result) {
+	if f.Verified != nil && *f.Verified {
+		return
+	}
+
+	if result.Err != nil {
+		f.VerificationError = result.Err.Error()
+		return
+	}
+
+	verified := result.Verified
+	f.Verified = &verified
+	f.VerificationError = ""
+	if len(result.Metadata) > 0 {
+		if f.Metadata == nil {
+			f.Metadata = make(map[string]string, len(result.Metadata))
+		}
+		for k, val := range result.Metadata {
+			f.Metadata[k] = val
+		}
+	}
+}